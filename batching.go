@@ -73,6 +73,15 @@ func (b *BatchOrder[BatchResponseType]) SendBatch(
 	signer *Signer,
 ) ([]BatchResponseType, error) {
 
+	// validate the whole batch up front so a bad order never leaves it half sent
+	for _, order := range b.Payload {
+		if n, ok := order.(normalizer); ok {
+			if err := n.normalize(cl.RoundingPolicy()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	batchSize := len(b.Payload)
 	var wg sync.WaitGroup
 
@@ -82,17 +91,7 @@ func (b *BatchOrder[BatchResponseType]) SendBatch(
 	for i, order := range b.Payload {
 		go func() {
 			defer wg.Done()
-			order.Build(cl)
-			sig, err := Sign(order, string(intent), signer)
-			if err != nil {
-				errCh <- err
-				return
-			}
-			path := batchIntentMap[intent]
-			resp, err := cl.Do(ctx, "POST", path, SignedMessage[Signable]{
-				Data:      order,
-				Signature: sig,
-			})
+			resp, err := sendSigned(ctx, cl, signer, string(intent), batchIntentMap[intent], order)
 			if err != nil {
 				errCh <- err
 				return
@@ -15,9 +15,6 @@ import (
 var batchTestRPCConfig []byte
 
 func TestSendBatch_twoCreateOrders(t *testing.T) {
-	prev := DomainHash
-	t.Cleanup(func() { DomainHash = prev })
-
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case r.Method == http.MethodGet && r.URL.Path == "/v1/rpc/config":
@@ -46,14 +43,14 @@ func TestSendBatch_twoCreateOrders(t *testing.T) {
 	defer ts.Close()
 
 	pk := "0bb5d63b84421e1268dda020818ae30cf26e7f10e321fb820a8aa69216dea92a"
-	cl, err := NewClient(context.Background(), pk, Environment(ts.URL))
+	cl, err := NewClient(context.Background(), WithPrivateKey(pk), WithBaseURL(ts.URL))
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	orders := []*Order{
-		NewRawOrder(ORDER_LIMIT, 1, PERPETUAL, 1, 100, false, BUY, TIF_GTD),
-		NewRawOrder(ORDER_LIMIT, 1, PERPETUAL, 1, 101, false, BUY, TIF_GTD),
+		NewRawOrder(ORDER_LIMIT, 1, PERPETUAL, DecimalFromInt(1), DecimalFromInt(100), false, BUY, TIF_GTD),
+		NewRawOrder(ORDER_LIMIT, 1, PERPETUAL, DecimalFromInt(1), DecimalFromInt(101), false, BUY, TIF_GTD),
 	}
 	batch := NewOrderBatch(orders)
 	out, err := batch.SendBatch(context.Background(), cl, Create, cl.account)
@@ -66,9 +63,6 @@ func TestSendBatch_twoCreateOrders(t *testing.T) {
 }
 
 func TestSendBatch_cancelIntent_usesCancelOrderPrimaryType(t *testing.T) {
-	prev := DomainHash
-	t.Cleanup(func() { DomainHash = prev })
-
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case r.Method == http.MethodGet && r.URL.Path == "/v1/rpc/config":
@@ -92,13 +86,15 @@ func TestSendBatch_cancelIntent_usesCancelOrderPrimaryType(t *testing.T) {
 	defer ts.Close()
 
 	pk := "0bb5d63b84421e1268dda020818ae30cf26e7f10e321fb820a8aa69216dea92a"
-	cl, err := NewClient(context.Background(), pk, Environment(ts.URL))
+	cl, err := NewClient(context.Background(), WithPrivateKey(pk), WithBaseURL(ts.URL))
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	c1 := NewCancel("order-a")
-	c1.Build(cl)
+	if err := c1.Build(cl); err != nil {
+		t.Fatal(err)
+	}
 
 	if _, err := Sign(c1, "TradeOrder", cl.account); err == nil {
 		t.Fatal("TradeOrder primary type must not apply to OrderCancel EIP-712 message")
@@ -0,0 +1,139 @@
+package etherealRest
+
+import (
+	"fmt"
+	"time"
+)
+
+// -------- BEGIN ORDER BUILDER -------- //
+
+// OrderBuilder assembles an Order for a Product field by field, e.g.
+//
+//	order, err := p.Limit(rest.BUY).Qty(q).Price(px).PostOnly().GTD(expiry).ClientID(id).Build()
+//
+// Incompatible combinations are reported by Build.
+type OrderBuilder struct {
+	order Order
+}
+
+// Limit starts a good-till-date limit order.
+func (p *Product) Limit(side OrderSide) *OrderBuilder {
+	return p.newBuilder(ORDER_LIMIT, side, TIF_GTD)
+}
+
+// Market starts an immediate-or-cancel market order.
+func (p *Product) Market(side OrderSide) *OrderBuilder {
+	return p.newBuilder(ORDER_MARKET, side, TIF_IOC)
+}
+
+func (p *Product) newBuilder(orderType OrderType, side OrderSide, tif TimeInForce) *OrderBuilder {
+	return &OrderBuilder{order: Order{
+		Type:        orderType,
+		Side:        side,
+		OnchainID:   p.OnchainID,
+		EngineType:  p.EngineType,
+		TimeInForce: tif,
+		product:     p,
+	}}
+}
+
+func (b *OrderBuilder) Qty(q Decimal) *OrderBuilder {
+	b.order.Quantity = q
+	return b
+}
+
+func (b *OrderBuilder) Price(px Decimal) *OrderBuilder {
+	b.order.Price = px
+	return b
+}
+
+// PostOnly rejects the order instead of letting it take liquidity.
+func (b *OrderBuilder) PostOnly() *OrderBuilder {
+	b.order.PostOnly = true
+	return b
+}
+
+// ReduceOnly only allows the order to shrink an open position.
+func (b *OrderBuilder) ReduceOnly() *OrderBuilder {
+	b.order.ReduceOnly = true
+	return b
+}
+
+// Close closes the whole open position; the quantity may be left at zero.
+func (b *OrderBuilder) Close() *OrderBuilder {
+	b.order.Close = true
+	return b
+}
+
+// GTD rests the order until expiry.
+func (b *OrderBuilder) GTD(expiry time.Time) *OrderBuilder {
+	b.order.TimeInForce = TIF_GTD
+	b.order.ExpiresAt = expiry.Unix()
+	b.order.ttl = 0
+	return b
+}
+
+// ExpiresIn rests the order for d after it is signed, measured on the venue's
+// clock once the client has synced it (see Client.SyncClock).
+func (b *OrderBuilder) ExpiresIn(d time.Duration) *OrderBuilder {
+	b.order.TimeInForce = TIF_GTD
+	b.order.ExpiresAt = 0
+	b.order.ttl = d
+	return b
+}
+
+func (b *OrderBuilder) IOC() *OrderBuilder {
+	b.order.TimeInForce = TIF_IOC
+	return b
+}
+
+func (b *OrderBuilder) FOK() *OrderBuilder {
+	b.order.TimeInForce = TIF_FOK
+	return b
+}
+
+// ClientID tags the order with a client order id, which also makes it safe
+// to retry.
+func (b *OrderBuilder) ClientID(id string) *OrderBuilder {
+	b.order.ClientOrderID = id
+	return b
+}
+
+// Build checks the order for incompatible settings and returns a copy ready
+// to send. Product constraints are applied when it is sent.
+func (b *OrderBuilder) Build() (*Order, error) {
+	o := b.order
+	invalid := func(format string, args ...any) (*Order, error) {
+		return nil, fmt.Errorf("%w: "+format, append([]any{ErrInvalidOrder}, args...)...)
+	}
+
+	switch {
+	case o.Type == ORDER_MARKET && o.PostOnly:
+		return invalid("postOnly is not allowed on market orders")
+	case o.PostOnly && o.TimeInForce != TIF_GTD:
+		return invalid("postOnly is not allowed with %s", o.TimeInForce)
+	case o.ReduceOnly && o.Close:
+		return invalid("reduceOnly and close are mutually exclusive")
+	case o.Type == ORDER_MARKET && !o.Price.IsZero():
+		return invalid("market orders do not take a price")
+	case o.Type == ORDER_LIMIT && o.Price.Sign() <= 0:
+		return invalid("limit orders require a positive price")
+	case o.Quantity.Sign() < 0 || (o.Quantity.IsZero() && !o.Close):
+		return invalid("quantity must be positive")
+	case (o.ExpiresAt != 0 || o.ttl != 0) && o.TimeInForce != TIF_GTD:
+		return invalid("expiry is only valid for GTD orders")
+	case o.ttl < 0:
+		return invalid("expiry duration %s is negative", o.ttl)
+	}
+	if o.ClientOrderID != "" {
+		if err := ValidateClientOrderID(o.ClientOrderID); err != nil {
+			return nil, err
+		}
+	}
+	if err := o.validateStop(); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// -------- END ORDER BUILDER -------- //
@@ -0,0 +1,50 @@
+package etherealRest
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOrderBuilder_limit(t *testing.T) {
+	p := testProduct()
+	expiry := time.Now().Add(time.Hour)
+	o, err := p.Limit(SELL).Qty(MustDecimal("0.5")).Price(MustDecimal("2000")).PostOnly().GTD(expiry).ClientID("cl1").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Type != ORDER_LIMIT || o.Side != SELL || o.OnchainID != p.OnchainID || !o.PostOnly ||
+		o.TimeInForce != TIF_GTD || o.ExpiresAt != expiry.Unix() || o.ClientOrderID != "cl1" || o.product != p {
+		t.Fatalf("built: %+v", o)
+	}
+
+	closing, err := p.Market(BUY).Close().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closing.TimeInForce != TIF_IOC || !closing.Close {
+		t.Fatalf("built: %+v", closing)
+	}
+	if err := p.Validate(closing, RoundingPolicy{}); err != nil {
+		t.Fatalf("close orders without quantity must validate: %v", err)
+	}
+}
+
+func TestOrderBuilder_incompatible(t *testing.T) {
+	p := testProduct()
+	one, px := MustDecimal("1"), MustDecimal("100")
+	cases := map[string]*OrderBuilder{
+		"market+postOnly":     p.Market(BUY).Qty(one).PostOnly(),
+		"fok+postOnly":        p.Limit(BUY).Qty(one).Price(px).FOK().PostOnly(),
+		"reduceOnly+close":    p.Limit(SELL).Qty(one).Price(px).ReduceOnly().Close(),
+		"market+price":        p.Market(BUY).Qty(one).Price(px),
+		"limit without price": p.Limit(BUY).Qty(one),
+		"zero quantity":       p.Limit(BUY).Price(px),
+		"ioc+expiry":          p.Limit(BUY).Qty(one).Price(px).GTD(time.Now().Add(time.Hour)).IOC(),
+	}
+	for name, b := range cases {
+		if _, err := b.Build(); !errors.Is(err, ErrInvalidOrder) {
+			t.Fatalf("%s: expected ErrInvalidOrder, got %v", name, err)
+		}
+	}
+}
@@ -7,9 +7,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
@@ -24,9 +29,28 @@ const (
 )
 
 type Client struct {
-	BaseURL string
-	Http    *http.Client
-	account *Signer
+	BaseURL     string
+	Http        *http.Client
+	account     *Signer
+	retryPolicy *RetryPolicy
+	limiter     *RateLimiter
+	rounding    RoundingPolicy
+	cloids      *ClientOrderIDGenerator
+	owner       string // wallet owning the subaccount when trading as a linked signer
+	clock       clock
+	ownsKey     bool                             // the signer was loaded by NewClient, so Close zeroes it
+	ownsHttp    bool                             // Http was built by NewClient, so Close releases its connections
+	schemaDrift atomic.Pointer[SchemaDriftError] // set when the typed data is loaded
+	allowDrift  bool
+	logger      *slog.Logger
+	userAgent   string
+
+	// subaccount selection, applied by InitSubaccount
+	subaccountName string
+	subaccountID   string
+
+	initMu      sync.Mutex
+	initialized bool
 }
 
 func (e *Client) GetSubaccount() *Subaccount {
@@ -37,16 +61,46 @@ func (e *Client) GetTypes() *abi.TypedData {
 	return e.account.GetTypes()
 }
 
+// Nonces returns the signer's NonceSource, used for every order and cancel.
+func (e *Client) Nonces() NonceSource {
+	return e.account.Nonces()
+}
+
+// Do sends a JSON request to the venue and returns the raw response body.
+// Non-2xx responses are returned as *APIError. GET requests are retried
+// according to the client's RetryPolicy.
 func (e *Client) Do(ctx context.Context, method, path string, body any) ([]byte, error) {
 	b, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequestWithContext(ctx, method, e.BaseURL+path, bytes.NewReader(b))
+	return e.retry(ctx, method == http.MethodGet, func(int) ([]byte, error) {
+		return e.do(ctx, method, path, b)
+	})
+}
+
+func (e *Client) do(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	if err := e.waitLimit(ctx, method, path); err != nil {
+		return nil, err
+	}
+	return e.send(ctx, method, path, body)
+}
+
+// waitLimit blocks until the rate limiter admits the request.
+func (e *Client) waitLimit(ctx context.Context, method, path string) error {
+	if e.limiter == nil {
+		return nil
+	}
+	return e.limiter.Wait(ctx, ClassifyRequest(method, path))
+}
+
+// send makes a single attempt at the request, without throttling.
+func (e *Client) send(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, e.BaseURL+path, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", USER_AGENT)
+	req.Header.Set("User-Agent", e.getUserAgent())
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := e.Http.Do(req)
@@ -60,12 +114,136 @@ func (e *Client) Do(ctx context.Context, method, path string, body any) ([]byte,
 		return nil, err
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("ethereal error %d: %s", resp.StatusCode, out.String())
+		return nil, newAPIError(resp, method, path, out.Bytes())
 	}
 	return out.Bytes(), nil
 }
 
-func NewClient(ctx context.Context, pk string, env Environment) (*Client, error) {
+// NewClient builds a client for the venue. Unless WithLazyInit is given it
+// fetches the EIP-712 config (skipped with WithTypedData) and resolves the
+// subaccount before returning.
+func NewClient(ctx context.Context, opts ...Option) (*Client, error) {
+	o := clientOptions{baseURL: string(Testnet)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	client := &Client{
+		BaseURL:        o.baseURL,
+		Http:           o.http,
+		logger:         o.logger,
+		userAgent:      o.userAgent,
+		rounding:       o.rounding,
+		cloids:         o.cloids,
+		allowDrift:     o.allowDrift,
+		subaccountName: o.subaccountName,
+		subaccountID:   o.subaccountID,
+	}
+	if client.Http == nil {
+		client.Http = newHTTPClient()
+		client.ownsHttp = true
+	}
+
+	client.SetRetryPolicy(DefaultRetryPolicy)
+	if o.retryPolicy != nil {
+		client.SetRetryPolicy(*o.retryPolicy)
+	}
+	client.SetRateLimiter(NewRateLimiter(DefaultRateLimits))
+	if o.limiterSet {
+		client.SetRateLimiter(o.limiter)
+	}
+
+	hasKey := o.signer != nil || o.digestSigner != nil || o.loadSigner != nil || o.privateKey != ""
+	if o.watchAddress != "" && hasKey && !o.readOnly {
+		return nil, errors.New("WithWatchAddress makes the client read-only and cannot be combined with a key")
+	}
+
+	switch {
+	case o.readOnly || o.watchAddress != "":
+		client.account = &Signer{}
+		if o.watchAddress != "" {
+			signer, err := NewWatchSigner(o.watchAddress)
+			if err != nil {
+				return nil, err
+			}
+			client.account = signer
+		}
+	case o.signer != nil:
+		client.account = o.signer
+	case o.digestSigner != nil:
+		client.account = NewDigestSigner(o.digestSigner)
+	case o.loadSigner != nil:
+		signer, err := o.loadSigner()
+		if err != nil {
+			return nil, err
+		}
+		client.account = signer
+		client.ownsKey = true
+	case o.privateKey != "":
+		// parse key, set address
+		pk := strings.TrimPrefix(o.privateKey, "0x")
+		ecdsa, err := crypto.HexToECDSA(pk)
+		if err != nil {
+			return nil, err
+		}
+		client.account = NewSigner(ecdsa)
+		client.ownsKey = true
+	default:
+		return nil, errors.New("no private key provided; use WithPrivateKey, WithKeystore, WithMnemonic, WithSigner, WithDigestSigner or NewPublicClient")
+	}
+
+	if o.nonces != nil {
+		client.account.SetNonceSource(o.nonces)
+	}
+	if o.owner != "" {
+		if !common.IsHexAddress(o.owner) {
+			return nil, fmt.Errorf("invalid linked signer owner %q", o.owner)
+		}
+		if client.account.ReadOnly() {
+			return nil, errors.New("WithLinkedSigner needs the linked signer's key")
+		}
+		client.owner = common.HexToAddress(o.owner).Hex()
+	}
+
+	if o.types != nil {
+		if _, err := client.useTypes(o.types); err != nil {
+			return nil, errors.Join(errors.New("unable to compute domain hash: "), err)
+		}
+	}
+
+	if o.lazy {
+		return client, nil
+	}
+	if err := client.Init(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// Close zeroes the key material and releases the idle connections of what
+// NewClient created itself: keys from WithPrivateKey, WithKeystore or
+// WithMnemonic, and the default HTTP client. Signers and HTTP clients passed
+// in are left to their owner. A client whose key was closed cannot sign.
+func (e *Client) Close() error {
+	if e.ownsHttp {
+		e.Http.CloseIdleConnections()
+	}
+	if !e.ownsKey {
+		return nil
+	}
+	return e.account.Close()
+}
+
+// NewPublicClient builds a read-only client that holds no key. It serves the
+// public endpoints, and with WithWatchAddress the account endpoints of that
+// wallet; signing methods return ErrReadOnly.
+func NewPublicClient(ctx context.Context, opts ...Option) (*Client, error) {
+	opts = append(opts, func(o *clientOptions) { o.readOnly = true })
+	return NewClient(ctx, opts...)
+}
+
+func newHTTPClient() *http.Client {
 	transport := &http.Transport{
 		MaxIdleConns:          100,
 		MaxIdleConnsPerHost:   100,
@@ -75,41 +253,64 @@ func NewClient(ctx context.Context, pk string, env Environment) (*Client, error)
 		DisableCompression:    true,
 		ForceAttemptHTTP2:     true,
 	}
-
-	client := &Client{
-		BaseURL: string(env),
-		Http: &http.Client{
-			Transport: transport,
-			Timeout:   10 * time.Second,
-		},
+	return &http.Client{
+		Transport: transport,
+		Timeout:   10 * time.Second,
 	}
+}
 
-	// load pk
-	if pk == "" {
-		return nil, errors.New("no private key provided; ETHEREAL_PK not set in environment")
+// Init fetches whatever the client still needs to sign: the EIP-712 config
+// and the subaccount. It is a no-op once it has succeeded, and is called
+// implicitly by signing and account methods on clients built WithLazyInit.
+func (e *Client) Init(ctx context.Context) error {
+	e.initMu.Lock()
+	defer e.initMu.Unlock()
+	if e.initialized {
+		return nil
 	}
 
-	// parse key, set address
-	if len(pk) > 1 && pk[:2] == "0x" {
-		pk = pk[2:]
+	// ethereal env setup; read-only clients never sign
+	if e.account.GetTypes() == nil && !e.account.ReadOnly() {
+		e.log().Debug("fetching eip712 config", "baseUrl", e.BaseURL)
+		if _, err := e.InitDomain(ctx); err != nil {
+			return errors.Join(errors.New("unable to compute domain hash: "), err)
+		}
 	}
-	if ecdsa, err := crypto.HexToECDSA(pk); err == nil {
-		client.account = NewSigner(ecdsa)
-	} else {
+	if e.account.Subaccount == nil && e.account.Address != "" {
+		e.log().Debug("resolving subaccount", "sender", e.account.Address)
+		if err := e.InitSubaccount(ctx); err != nil {
+			return errors.Join(errors.New("failed to fetch subaccount: "), err)
+		}
+	}
+
+	e.initialized = true
+	return nil
+}
+
+// subaccount initializes the client if needed and returns its subaccount.
+func (e *Client) subaccount(ctx context.Context) (*Subaccount, error) {
+	if err := e.Init(ctx); err != nil {
 		return nil, err
 	}
-	// ethereal env setup
-	var err error
-	_, err = client.InitDomain(ctx)
-	if err != nil {
-		return nil, errors.Join(errors.New("unable to compute domain hash: "), err)
+	sub := e.GetSubaccount()
+	if sub == nil {
+		return nil, ErrNoSubaccount
 	}
+	return sub, nil
+}
 
-	if err := client.InitSubaccount(ctx); err != nil {
-		return nil, errors.Join(errors.New("failed to fetch subaccount: "), err)
+func (e *Client) log() *slog.Logger {
+	if e.logger == nil {
+		return slog.New(slog.DiscardHandler)
 	}
+	return e.logger
+}
 
-	return client, nil
+func (e *Client) getUserAgent() string {
+	if e.userAgent == "" {
+		return USER_AGENT
+	}
+	return e.userAgent
 }
 
 // ---------- REST ----------
@@ -155,18 +356,34 @@ func (e *Client) InitDomain(ctx context.Context) (string, error) {
 		Domain: resp.Domain,
 	}
 
-	e.account.SetTypes(types)
+	return e.useTypes(types)
+}
 
+// useTypes installs the EIP-712 types on the signer and precomputes the domain
+// hash. Types and domain are per client, so clients for different
+// environments can sign concurrently.
+func (e *Client) useTypes(types *abi.TypedData) (string, error) {
 	domain, err := types.HashStruct("EIP712Domain", types.Domain.Map())
 	if err != nil {
-		panic("failed to compute domain hash: " + err.Error())
+		return "", fmt.Errorf("failed to compute domain hash: %w", err)
+	}
+	e.account.SetTypes(types)
+	e.account.SetDomainHash(domain)
+
+	var drift *SchemaDriftError
+	if errors.As(CheckSchema(types), &drift) {
+		e.log().Warn("venue EIP-712 schema differs from the signed messages", "drift", drift.Error(), "allowed", e.allowDrift)
 	}
-	DomainHash = domain
+	e.schemaDrift.Store(drift)
 	return hex.EncodeToString(domain), nil
 }
 
 func (e *Client) InitSubaccount(ctx context.Context) error {
-	path := fmt.Sprintf("/v1/subaccount?sender=%s", e.account.Address)
+	owner := e.account.Address
+	if e.owner != "" {
+		owner = e.owner
+	}
+	path := fmt.Sprintf("/v1/subaccount?sender=%s", owner)
 	data, err := e.Do(ctx, "GET", path, nil)
 	if err != nil {
 		return err
@@ -178,9 +395,16 @@ func (e *Client) InitSubaccount(ctx context.Context) error {
 	if len(resp.Data) == 0 {
 		return errors.New("no subaccounts found")
 	}
-	e.account.Subaccount = &resp.Data[0] // NOTE: currently only one subaccount per client is supported
 
-	return nil
+	// NOTE: currently only one subaccount per client is supported
+	for i, sub := range resp.Data {
+		if (e.subaccountName == "" || strings.EqualFold(sub.Name, e.subaccountName)) &&
+			(e.subaccountID == "" || strings.EqualFold(sub.Id, e.subaccountID)) {
+			e.account.Subaccount = &resp.Data[i]
+			return nil
+		}
+	}
+	return fmt.Errorf("no subaccount matching name %q id %q", e.subaccountName, e.subaccountID)
 }
 
 // ---------- Methods ----------
@@ -191,7 +415,6 @@ type sendable interface {
 
 type Sendable[T sendable] interface {
 	Signable
-	Build(SubaccountHolder)
 	Send(context.Context, OrderClient, *Signer) (T, error)
 }
 
@@ -218,7 +441,11 @@ func (e *Client) CancelOrdersFromCreated(ctx context.Context, msg []*OrderCreate
 }
 
 func (e *Client) GetPosition(ctx context.Context) ([]*Position, error) {
-	path := fmt.Sprintf("/v1/position?subaccountId=%s&open=%v", e.account.Subaccount.Id, true)
+	sub, err := e.subaccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/v1/position?subaccountId=%s&open=%v", sub.Id, true)
 	data, err := e.Do(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
@@ -232,7 +459,11 @@ func (e *Client) GetPosition(ctx context.Context) ([]*Position, error) {
 }
 
 func (e *Client) GetAccountBalance(ctx context.Context) ([]*AccountBalance, error) {
-	path := fmt.Sprintf("/v1/subaccount/balance?subaccountId=%s", e.account.Subaccount.Id)
+	sub, err := e.subaccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/v1/subaccount/balance?subaccountId=%s", sub.Id)
 	data, err := e.Do(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
@@ -5,9 +5,11 @@ import (
 	"crypto/ecdsa"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -100,9 +102,6 @@ func TestClient_Do_contextCancel(t *testing.T) {
 }
 
 func TestInitDomain_setsTypesAndDomainHash(t *testing.T) {
-	prev := DomainHash
-	t.Cleanup(func() { DomainHash = prev })
-
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case r.Method == http.MethodGet && r.URL.Path == "/v1/rpc/config":
@@ -124,8 +123,8 @@ func TestInitDomain_setsTypesAndDomainHash(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(DomainHash) != 32 {
-		t.Fatalf("DomainHash len: %d", len(DomainHash))
+	if len(cl.account.GetDomainHash()) != 32 {
+		t.Fatalf("domain hash len: %d", len(cl.account.GetDomainHash()))
 	}
 	if domainHex == "" {
 		t.Fatal("empty domain hex")
@@ -191,9 +190,6 @@ func TestInitSubaccount_emptyErrors(t *testing.T) {
 }
 
 func TestNewClient_httptest(t *testing.T) {
-	prev := DomainHash
-	t.Cleanup(func() { DomainHash = prev })
-
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case r.Method == http.MethodGet && r.URL.Path == "/v1/rpc/config":
@@ -217,7 +213,7 @@ func TestNewClient_httptest(t *testing.T) {
 	defer ts.Close()
 
 	pk := "0bb5d63b84421e1268dda020818ae30cf26e7f10e321fb820a8aa69216dea92a"
-	cl, err := NewClient(context.Background(), pk, Environment(ts.URL))
+	cl, err := NewClient(context.Background(), WithPrivateKey(pk), WithBaseURL(ts.URL))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -226,7 +222,7 @@ func TestNewClient_httptest(t *testing.T) {
 	}
 }
 
-func mustECDSA(t *testing.T, hexKey string) *ecdsa.PrivateKey {
+func mustECDSA(t testing.TB, hexKey string) *ecdsa.PrivateKey {
 	t.Helper()
 	k, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
 	if err != nil {
@@ -234,3 +230,125 @@ func mustECDSA(t *testing.T, hexKey string) *ecdsa.PrivateKey {
 	}
 	return k
 }
+
+// newTestClient starts a venue stub serving rpc config and subaccount lookups,
+// delegating every other request to next, and returns a client wired to it.
+func newTestClient(t *testing.T, next http.HandlerFunc) *Client {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/rpc/config":
+			_, _ = w.Write(testRPCConfigJSON)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/subaccount":
+			sender := r.URL.Query().Get("sender")
+			resp := Response[[]Subaccount]{
+				Data: []Subaccount{{
+					Id:      "0x1111111111111111111111111111111111111111111111111111111111111111",
+					Name:    "0x2222222222222222222222222222222222222222222222222222222222222222",
+					Account: sender,
+				}},
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			next(w, r)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	pk := "0bb5d63b84421e1268dda020818ae30cf26e7f10e321fb820a8aa69216dea92a"
+	cl, err := NewClient(context.Background(), WithPrivateKey(pk), WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cl
+}
+
+func TestNewPublicClient_readsWithoutKey(t *testing.T) {
+	var posts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			posts.Add(1)
+		case r.URL.Path == "/v1/product":
+			_, _ = w.Write([]byte(`{"data":[{"id":"p1","ticker":"ETHUSD","onchainId":1}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	cl, err := NewPublicClient(context.Background(), WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	products, err := cl.GetProductMap(context.Background())
+	if err != nil || products["ETHUSD"].ID != "p1" {
+		t.Fatalf("products: %v %v", products, err)
+	}
+	if _, err := cl.GetPosition(context.Background()); !errors.Is(err, ErrNoSubaccount) {
+		t.Fatalf("expected ErrNoSubaccount, got %v", err)
+	}
+	order := NewRawOrder(ORDER_LIMIT, 1, PERPETUAL, DecimalFromInt(1), DecimalFromInt(100), false, BUY, TIF_GTD)
+	if _, err := cl.CreateOrder(context.Background(), order); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+	if posts.Load() != 0 {
+		t.Fatal("read-only client must not post")
+	}
+}
+
+func TestNewClient_watchAddress(t *testing.T) {
+	const wallet = "0x3333333333333333333333333333333333333333"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/subaccount":
+			if r.URL.Query().Get("sender") != wallet {
+				t.Errorf("sender: %s", r.URL.Query().Get("sender"))
+			}
+			_, _ = w.Write(testSubaccountFixture)
+		case "/v1/position":
+			if r.URL.Query().Get("subaccountId") != "0x1111111111111111111111111111111111111111111111111111111111111111" {
+				t.Errorf("subaccountId: %s", r.URL.Query().Get("subaccountId"))
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":"pos1"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	cl, err := NewClient(context.Background(), WithBaseURL(ts.URL), WithWatchAddress(wallet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	positions, err := cl.GetPosition(context.Background())
+	if err != nil || len(positions) != 1 {
+		t.Fatalf("positions: %v %v", positions, err)
+	}
+	if _, err := cl.CancelOrder(context.Background(), NewCancel("oid")); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+
+	if _, err := NewClient(context.Background(), WithBaseURL(ts.URL), WithWatchAddress(wallet), WithPrivateKey(testKeyHex)); err == nil {
+		t.Fatal("a watch address combined with a key must be rejected")
+	}
+}
+
+func TestSend_nilSigner(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	})
+	var signer *Signer
+	if !signer.ReadOnly() || signer.GetDigestSigner() != nil || signer.GetPk() != nil || signer.Close() != nil {
+		t.Fatal("a nil signer must be read-only")
+	}
+	order := NewRawOrder(ORDER_LIMIT, 1, PERPETUAL, DecimalFromInt(1), DecimalFromInt(100), false, BUY, TIF_GTD)
+	if _, err := order.Send(context.Background(), cl, signer); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+	if _, err := NewCancel("oid").Send(context.Background(), cl, signer); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+}
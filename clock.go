@@ -0,0 +1,175 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// -------- BEGIN CLOCK SYNC -------- //
+
+// clockSamples is the number of round trips measured per sync.
+const clockSamples = 5
+
+// Samples whose round trip exceeds twice the fastest one plus clockRTTSlack
+// were delayed on the way and are dropped before taking the median.
+const clockRTTSlack = 10 * time.Millisecond
+
+// ClockStatus is the result of the last clock sync against the venue.
+type ClockStatus struct {
+	Offset   time.Duration // venue clock minus local clock
+	RTT      time.Duration // median round trip of the samples
+	Samples  int           // samples the median was taken over, after dropping slow ones
+	SyncedAt time.Time     // local time of the sync, zero if never synced
+}
+
+// clock holds the client's view of the venue's clock.
+type clock struct {
+	mu     sync.RWMutex
+	status ClockStatus
+}
+
+// offsetSetter is implemented by nonce sources that follow the venue's clock,
+// such as MonotonicNonce.
+type offsetSetter interface {
+	SetOffset(time.Duration)
+}
+
+// SyncClock measures the offset between the local and venue clocks with a few
+// NTP-style round trips to /v1/time. Samples with a slow round trip are
+// dropped and the median of the rest discards skewed ones. The offset then corrects Now, order nonces and SignedAt, and
+// expiries set with OrderBuilder.ExpiresIn. It is applied to the signer's
+// NonceSource when that supports SetOffset; each signer has its own unless one
+// was shared through WithNonceSource or SetNonceSource.
+func (e *Client) SyncClock(ctx context.Context) (ClockStatus, error) {
+	var offsets, rtts []time.Duration
+	var errs []error
+	for range clockSamples {
+		offset, rtt, err := e.sampleClock(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return e.ClockStatus(), err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		offsets = append(offsets, offset)
+		rtts = append(rtts, rtt)
+	}
+	if len(offsets) == 0 {
+		return e.ClockStatus(), fmt.Errorf("clock sync: %w", errors.Join(errs...))
+	}
+	offsets, rtts = dropSlowSamples(offsets, rtts)
+
+	status := ClockStatus{
+		Offset:   median(offsets),
+		RTT:      median(rtts),
+		Samples:  len(offsets),
+		SyncedAt: time.Now(),
+	}
+	e.clock.mu.Lock()
+	e.clock.status = status
+	e.clock.mu.Unlock()
+	if n, ok := e.Nonces().(offsetSetter); ok {
+		n.SetOffset(status.Offset)
+	}
+	e.log().Debug("clock synced", "offset", status.Offset, "rtt", status.RTT, "samples", status.Samples)
+	return status, nil
+}
+
+// sampleClock assumes the venue stamped its reply halfway through the round
+// trip. Only the request itself is timed: the rate limiter is waited on first
+// and a failed attempt is not retried, so neither inflates the round trip.
+func (e *Client) sampleClock(ctx context.Context) (offset, rtt time.Duration, err error) {
+	if err := e.waitLimit(ctx, http.MethodGet, "/v1/time"); err != nil {
+		return 0, 0, err
+	}
+	sent := time.Now()
+	data, err := e.send(ctx, http.MethodGet, "/v1/time", nil)
+	received := time.Now()
+	if err != nil {
+		return 0, 0, err
+	}
+	var resp struct {
+		Timestamp int64 `json:"timestamp"` // milliseconds since epoch
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return 0, 0, err
+	}
+	if resp.Timestamp <= 0 {
+		return 0, 0, fmt.Errorf("clock sync: no timestamp in %s", data)
+	}
+
+	rtt = received.Sub(sent)
+	midpoint := sent.Add(rtt / 2)
+	return time.UnixMilli(resp.Timestamp).Sub(midpoint), rtt, nil
+}
+
+// StartClockSync syncs the clock every interval until ctx is done. Failed
+// refreshes are logged and keep the previous offset.
+func (e *Client) StartClockSync(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("clock sync interval must be positive, got %s", interval)
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := e.SyncClock(ctx); err != nil && ctx.Err() == nil {
+				e.log().Warn("clock sync failed", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// ClockStatus returns the result of the last successful sync.
+func (e *Client) ClockStatus() ClockStatus {
+	e.clock.mu.RLock()
+	defer e.clock.mu.RUnlock()
+	return e.clock.status
+}
+
+// ClockOffset returns how far the venue's clock is ahead of the local one.
+func (e *Client) ClockOffset() time.Duration {
+	return e.ClockStatus().Offset
+}
+
+// Now returns the current time on the venue's clock.
+func (e *Client) Now() time.Time {
+	return time.Now().Add(e.ClockOffset())
+}
+
+// dropSlowSamples keeps the samples whose round trip is within twice the
+// fastest one plus clockRTTSlack.
+func dropSlowSamples(offsets, rtts []time.Duration) (keptOffsets, keptRTTs []time.Duration) {
+	limit := 2*slices.Min(rtts) + clockRTTSlack
+	for i, rtt := range rtts {
+		if rtt <= limit {
+			keptOffsets = append(keptOffsets, offsets[i])
+			keptRTTs = append(keptRTTs, rtt)
+		}
+	}
+	return keptOffsets, keptRTTs
+}
+
+func median(ds []time.Duration) time.Duration {
+	s := slices.Clone(ds)
+	slices.Sort(s)
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
+
+// -------- END CLOCK SYNC -------- //
@@ -0,0 +1,185 @@
+package etherealRest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSyncClock(t *testing.T) {
+	const skew = 30 * time.Second
+	var calls atomic.Int32
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/time" {
+			http.NotFound(w, r)
+			return
+		}
+		switch calls.Add(1) {
+		case 2: // an outlier the median must discard
+			fmt.Fprintf(w, `{"timestamp":%d}`, time.Now().Add(time.Hour).UnixMilli())
+		case 4:
+			http.Error(w, `{"message":"boom"}`, http.StatusInternalServerError)
+		default:
+			fmt.Fprintf(w, `{"timestamp":%d}`, time.Now().Add(skew).UnixMilli())
+		}
+	})
+	nonces, _ := NewMonotonicNonce(nil)
+	cl.account.SetNonceSource(nonces)
+
+	status, err := cl.SyncClock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := status.Offset - skew; d < -time.Second || d > time.Second {
+		t.Fatalf("offset %s, want about %s", status.Offset, skew)
+	}
+	if status.Samples != clockSamples-1 || status.SyncedAt.IsZero() || cl.ClockOffset() != status.Offset {
+		t.Fatalf("status %+v", status)
+	}
+	if nonces.Offset() != status.Offset {
+		t.Fatalf("nonce offset %s, want %s", nonces.Offset(), status.Offset)
+	}
+	if d := cl.Now().Sub(time.Now().Add(skew)); d < -time.Second || d > time.Second {
+		t.Fatalf("Now is off by %s", d)
+	}
+
+	p := testProduct()
+	o, err := p.Limit(BUY).Qty(MustDecimal("1")).Price(MustDecimal("100")).ExpiresIn(time.Minute).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Build(cl); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Now().Add(skew).Unix(); o.SignedAt < want-1 || o.SignedAt > want+1 {
+		t.Fatalf("signedAt %d, want about %d", o.SignedAt, want)
+	}
+	if o.ExpiresAt != o.SignedAt+60 {
+		t.Fatalf("expiresAt %d, signedAt %d", o.ExpiresAt, o.SignedAt)
+	}
+}
+
+// skewedClient is a test client whose venue clock runs skew ahead of the local one.
+func skewedClient(t *testing.T, skew time.Duration) *Client {
+	t.Helper()
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"timestamp":%d}`, time.Now().Add(skew).UnixMilli())
+	})
+}
+
+func TestSyncClock_offsetsArePerClient(t *testing.T) {
+	ahead, behind := skewedClient(t, time.Hour), skewedClient(t, -time.Hour)
+	for _, cl := range []*Client{ahead, behind} {
+		if _, err := cl.SyncClock(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ahead.Nonces() == behind.Nonces() {
+		t.Fatal("clients share a nonce source")
+	}
+
+	p := testProduct()
+	for _, c := range []struct {
+		cl   *Client
+		skew time.Duration
+	}{{ahead, time.Hour}, {behind, -time.Hour}} {
+		o, _ := p.Limit(BUY).Qty(MustDecimal("1")).Price(MustDecimal("100")).Build()
+		if err := o.Build(c.cl); err != nil {
+			t.Fatal(err)
+		}
+		if want := time.Now().Add(c.skew).Unix(); o.SignedAt < want-1 || o.SignedAt > want+1 {
+			t.Fatalf("skew %s: signedAt %d, want about %d", c.skew, o.SignedAt, want)
+		}
+	}
+}
+
+func TestOrderBuild_pastExpiryOnVenueClock(t *testing.T) {
+	ahead, behind := skewedClient(t, time.Hour), skewedClient(t, -time.Hour)
+	for _, cl := range []*Client{ahead, behind} {
+		if _, err := cl.SyncClock(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := testProduct()
+	order := func(expiry time.Time) *Order {
+		o, err := p.Limit(BUY).Qty(MustDecimal("1")).Price(MustDecimal("100")).GTD(expiry).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return o
+	}
+
+	// half an hour from now locally has already passed on a venue an hour ahead
+	if err := order(time.Now().Add(30 * time.Minute)).Build(ahead); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("expected ErrInvalidOrder, got %v", err)
+	}
+	// and half an hour ago locally is still ahead of a venue an hour behind
+	if err := order(time.Now().Add(-30 * time.Minute)).Build(behind); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartClockSync_rejectsBadInterval(t *testing.T) {
+	cl := skewedClient(t, 0)
+	if err := cl.StartClockSync(context.Background(), 0); err == nil {
+		t.Fatal("expected an interval error")
+	}
+}
+
+func TestSyncClock_timesOnlyTheRoundTrip(t *testing.T) {
+	const skew = 30 * time.Second
+	var calls atomic.Int32
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 3 { // held up before stamping, as by a congested link
+			time.Sleep(80 * time.Millisecond)
+		}
+		fmt.Fprintf(w, `{"timestamp":%d}`, time.Now().Add(skew).UnixMilli())
+	})
+	// every sample after the first waits about 50ms for a token
+	cl.SetRateLimiter(NewRateLimiter(map[EndpointClass]RateLimit{ClassPublic: {Rate: 20, Burst: 1}}))
+
+	status, err := cl.SyncClock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Samples != 2 || status.RTT > 40*time.Millisecond {
+		t.Fatalf("slow samples or limiter waits were counted: %+v", status)
+	}
+	if d := status.Offset - skew; d < -15*time.Millisecond || d > 15*time.Millisecond {
+		t.Fatalf("offset %s, want about %s", status.Offset, skew)
+	}
+}
+
+func TestSyncClock_allSamplesFail(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"boom"}`, http.StatusInternalServerError)
+	})
+	if _, err := cl.SyncClock(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if !cl.ClockStatus().SyncedAt.IsZero() {
+		t.Fatal("a failed sync must not record a status")
+	}
+}
+
+func TestDropSlowSamples(t *testing.T) {
+	ms := time.Millisecond
+	offsets, rtts := dropSlowSamples([]time.Duration{1, 2, 3, 4}, []time.Duration{20 * ms, 50 * ms, 51 * ms, 400 * ms})
+	if !slices.Equal(offsets, []time.Duration{1, 2}) || !slices.Equal(rtts, []time.Duration{20 * ms, 50 * ms}) {
+		t.Fatalf("kept offsets %v rtts %v", offsets, rtts)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	if m := median([]time.Duration{5, 1, 3}); m != 3 {
+		t.Fatalf("odd median %d", m)
+	}
+	if m := median([]time.Duration{4, 1, 3, 2}); m != 2 {
+		t.Fatalf("even median %d", m)
+	}
+}
@@ -0,0 +1,204 @@
+package etherealRest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// -------- BEGIN CLIENT ORDER IDS -------- //
+
+// MaxClientOrderIDLen is the longest alphanumeric client order id the venue
+// accepts; UUIDs are accepted as well.
+const (
+	MaxClientOrderIDLen = 32
+	maxClientOrderIDTag = 8
+)
+
+// ValidateClientOrderID checks id is a UUID or an alphanumeric string of at
+// most MaxClientOrderIDLen characters.
+func ValidateClientOrderID(id string) error {
+	if isUUID(id) {
+		return nil
+	}
+	if id == "" || len(id) > MaxClientOrderIDLen || !isAlphanumeric(id) {
+		return fmt.Errorf("%w: client order id %q must be a UUID or up to %d alphanumeric characters", ErrInvalidOrder, id, MaxClientOrderIDLen)
+	}
+	return nil
+}
+
+// ClientOrderIDGenerator issues unique client order ids of the form
+// tag + time + sequence + random, all alphanumeric. It is safe for concurrent
+// use.
+type ClientOrderIDGenerator struct {
+	tag string
+	seq atomic.Uint32
+}
+
+// NewClientOrderIDGenerator returns a generator whose ids start with tag, an
+// optional strategy label of up to 8 alphanumeric characters.
+func NewClientOrderIDGenerator(tag string) (*ClientOrderIDGenerator, error) {
+	if len(tag) > maxClientOrderIDTag || !isAlphanumeric(tag) {
+		return nil, fmt.Errorf("client order id tag %q must be up to %d alphanumeric characters", tag, maxClientOrderIDTag)
+	}
+	return &ClientOrderIDGenerator{tag: tag}, nil
+}
+
+// Next returns a new client order id, at most 29 characters long.
+func (g *ClientOrderIDGenerator) Next() string {
+	var r [8]byte
+	rand.Read(r[:]) // never fails, see crypto/rand
+
+	var b strings.Builder
+	b.WriteString(g.tag)
+	writeBase36(&b, uint64(time.Now().UnixMilli()), 9)
+	writeBase36(&b, uint64(g.seq.Add(1)%(36*36*36*36)), 4)
+	writeBase36(&b, binary.BigEndian.Uint64(r[:])%(36*36*36*36*36*36*36*36), 8)
+	return b.String()
+}
+
+// Owns reports whether id was issued by a generator with the same tag.
+func (g *ClientOrderIDGenerator) Owns(id string) bool {
+	return strings.HasPrefix(id, g.tag) && len(id) == len(g.tag)+21
+}
+
+// SetClientOrderIDs makes the client assign an id from g to every order sent
+// without one, which also makes those orders safe to retry. nil disables it.
+func (e *Client) SetClientOrderIDs(g *ClientOrderIDGenerator) {
+	e.cloids = g
+}
+
+func (e *Client) ClientOrderIDs() *ClientOrderIDGenerator {
+	return e.cloids
+}
+
+// cloidSource is implemented by clients that can assign client order ids.
+type cloidSource interface {
+	ClientOrderIDs() *ClientOrderIDGenerator
+}
+
+// assignClientOrderID gives o an id from the client's generator, if it has one
+// and the order is not tagged yet.
+func (o *Order) assignClientOrderID(cl SubaccountHolder) {
+	if o.ClientOrderID != "" {
+		return
+	}
+	if h, ok := cl.(cloidSource); ok && h.ClientOrderIDs() != nil {
+		o.ClientOrderID = h.ClientOrderIDs().Next()
+	}
+}
+
+// cloidLookupLimit is the page size of a client order id lookup.
+const cloidLookupLimit = 50
+
+// GetOrderByClientID returns the subaccount's order with the given client
+// order id, or ErrOrderNotFound. The venue filters by client order id, so only
+// the first page of results is read; it is never a walk of the order history.
+func (e *Client) GetOrderByClientID(ctx context.Context, cloid string) (*OrderInfo, error) {
+	sub, err := e.subaccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter := OrderFilter{ClientOrderID: cloid, PageOptions: PageOptions{Limit: cloidLookupLimit}}
+	for page, err := range Pages[OrderInfo](ctx, e, "/v1/order", filter.values(sub.Id), filter.PageOptions) {
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Data {
+			if page.Data[i].ClientOrderID == cloid {
+				return &page.Data[i], nil
+			}
+		}
+		break
+	}
+	return nil, fmt.Errorf("%w: client order id %s", ErrOrderNotFound, cloid)
+}
+
+// CancelByClientID cancels one or more orders by client order id in a single
+// request.
+func (e *Client) CancelByClientID(ctx context.Context, cloids ...string) ([]*OrderCancelled, error) {
+	return NewCancelCloids(cloids...).Send(ctx, e, e.account)
+}
+
+// ReplaceByClientID cancels the order tagged cloid and then sends
+// replacement. The replacement is only sent once the venue reports the old
+// order cancelled, so at most one of the two orders is ever working; a
+// partial fill of the old order before the cancel lands is still possible and
+// reported in the cancel's Filled. If the old order was not cancelled, e.g.
+// because it already filled, nothing is sent and the error matches
+// ErrOrderNotFound or carries the venue's cancel result.
+func (e *Client) ReplaceByClientID(ctx context.Context, cloid string, replacement *Order) (OrderCreated, []*OrderCancelled, error) {
+	if replacement.ClientOrderID == cloid {
+		return OrderCreated{}, nil, fmt.Errorf("%w: replacement reuses client order id %s", ErrInvalidOrder, cloid)
+	}
+	cancelled, err := e.CancelByClientID(ctx, cloid)
+	if err != nil {
+		return OrderCreated{}, nil, err
+	}
+	if err := cancelResult(cancelled, cloid); err != nil {
+		return OrderCreated{}, cancelled, err
+	}
+	created, err := replacement.Send(ctx, e, e.account)
+	return created, cancelled, err
+}
+
+// cancelResult reports whether cloid was cancelled according to the venue's
+// per-order results.
+func cancelResult(cancelled []*OrderCancelled, cloid string) error {
+	for _, c := range cancelled {
+		if c == nil || c.Cloid != cloid {
+			continue
+		}
+		switch result := strings.ToLower(strings.ReplaceAll(c.Result, "_", "")); result {
+		case "ok":
+			return nil
+		case "notfound":
+			return fmt.Errorf("%w: client order id %s", ErrOrderNotFound, cloid)
+		}
+		return fmt.Errorf("order %s was not cancelled: %s", cloid, c.Result)
+	}
+	return fmt.Errorf("%w: no cancel result for client order id %s", ErrOrderNotFound, cloid)
+}
+
+func writeBase36(b *strings.Builder, v uint64, width int) {
+	s := strconv.FormatUint(v, 36)
+	for range width - len(s) {
+		b.WriteByte('0')
+	}
+	b.WriteString(s)
+}
+
+func isAlphanumeric(s string) bool {
+	for _, c := range []byte(s) {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range []byte(s) {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// -------- END CLIENT ORDER IDS -------- //
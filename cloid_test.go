@@ -0,0 +1,176 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientOrderIDGenerator(t *testing.T) {
+	g, err := NewClientOrderIDGenerator("mm1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 500 {
+				id := g.Next()
+				if err := ValidateClientOrderID(id); err != nil || !g.Owns(id) {
+					t.Errorf("id %q: %v", id, err)
+				}
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("duplicate id %q", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	if _, err := NewClientOrderIDGenerator("has-dash"); err == nil {
+		t.Fatal("expected tag error")
+	}
+	if _, err := NewClientOrderIDGenerator("waytoolongtag"); err == nil {
+		t.Fatal("expected tag error")
+	}
+}
+
+func TestValidateClientOrderID(t *testing.T) {
+	for _, id := range []string{"abc123", "9f1c2b3a-4d5e-4f60-8a9b-0c1d2e3f4a5b", "A1234567890123456789012345678901"} {
+		if err := ValidateClientOrderID(id); err != nil {
+			t.Fatalf("%q: %v", id, err)
+		}
+	}
+	for _, id := range []string{"", "a-b", "A12345678901234567890123456789012", "9f1c2b3a-4d5e-4f60-8a9b-0c1d2e3f4a5g"} {
+		if err := ValidateClientOrderID(id); !errors.Is(err, ErrInvalidOrder) {
+			t.Fatalf("%q: expected ErrInvalidOrder, got %v", id, err)
+		}
+	}
+}
+
+func TestClient_autoAssignsClientOrderIDs(t *testing.T) {
+	var mu sync.Mutex
+	var sent []string
+	var attempts int
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var msg struct{ Data Order }
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 { // the first attempt fails transiently, the retry must reuse the id
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		sent = append(sent, msg.Data.ClientOrderID)
+		_ = json.NewEncoder(w).Encode(OrderCreated{Id: "o", Cloid: msg.Data.ClientOrderID})
+	})
+	cl.SetRetryPolicy(RetryPolicy{MaxAttempts: 2})
+	g, _ := NewClientOrderIDGenerator("t")
+	cl.SetClientOrderIDs(g)
+
+	p := testProduct()
+	o, _ := p.Limit(BUY).Qty(MustDecimal("1")).Price(MustDecimal("100")).Build()
+	created, err := cl.CreateOrder(context.Background(), o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Owns(created.Cloid) || o.ClientOrderID != created.Cloid || attempts != 2 {
+		t.Fatalf("created %+v after %d attempts, order id %q", created, attempts, o.ClientOrderID)
+	}
+
+	batch := []*Order{
+		p.NewOrder(ORDER_LIMIT, MustDecimal("1"), MustDecimal("100"), false, BUY, TIF_GTD),
+		p.NewOrder(ORDER_LIMIT, MustDecimal("1"), MustDecimal("101"), false, SELL, TIF_GTD),
+	}
+	if _, err := cl.CreateOrders(context.Background(), batch); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 3 || sent[1] == sent[2] || !g.Owns(sent[1]) || !g.Owns(sent[2]) {
+		t.Fatalf("batch ids %v", sent)
+	}
+}
+
+func TestClient_byClientID(t *testing.T) {
+	var cancelled []string
+	var created []string
+	var lookups atomic.Int32
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/order":
+			lookups.Add(1)
+			switch r.URL.Query().Get("clientOrderId") {
+			case "known":
+				_, _ = w.Write([]byte(`{"data":[{"id":"o1","clientOrderId":"known","status":"NEW"}],"hasNext":false}`))
+			case "unfiltered": // a venue ignoring the filter returns the whole history
+				_, _ = w.Write([]byte(`{"data":[{"id":"o9","clientOrderId":"other"}],"hasNext":true,"nextCursor":"c"}`))
+			default:
+				_, _ = w.Write([]byte(`{"data":[],"hasNext":false}`))
+			}
+		case r.URL.Path == "/v1/order/cancel":
+			var msg struct{ Data OrderCancel }
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			cancelled = append(cancelled, msg.Data.Cloids...)
+			switch msg.Data.Cloids[0] {
+			case "known":
+				_, _ = w.Write([]byte(`{"data":[{"id":"o1","clientOrderId":"known","result":"Ok"}]}`))
+			case "filled":
+				_, _ = w.Write([]byte(`{"data":[{"id":"o3","clientOrderId":"filled","filled":"1","result":"AlreadyFilled"}]}`))
+			default:
+				_, _ = w.Write([]byte(`{"data":[{"clientOrderId":"` + msg.Data.Cloids[0] + `","result":"NotFound"}]}`))
+			}
+		case r.URL.Path == "/v1/order":
+			var msg struct{ Data Order }
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			created = append(created, msg.Data.ClientOrderID)
+			_ = json.NewEncoder(w).Encode(OrderCreated{Id: "o2", Cloid: msg.Data.ClientOrderID})
+		}
+	})
+	ctx := context.Background()
+
+	order, err := cl.GetOrderByClientID(ctx, "known")
+	if err != nil || order.Id != "o1" {
+		t.Fatalf("lookup: %+v %v", order, err)
+	}
+	if _, err := cl.GetOrderByClientID(ctx, "missing"); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("missing lookup: %v", err)
+	}
+	lookups.Store(0)
+	if _, err := cl.GetOrderByClientID(ctx, "unfiltered"); !errors.Is(err, ErrOrderNotFound) || lookups.Load() != 1 {
+		t.Fatalf("unfiltered lookup: %v after %d requests", err, lookups.Load())
+	}
+
+	p := testProduct()
+	next, _ := p.Limit(BUY).Qty(MustDecimal("1")).Price(MustDecimal("100")).ClientID("next").Build()
+	placed, old, err := cl.ReplaceByClientID(ctx, "known", next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placed.Cloid != "next" || len(old) != 1 || len(cancelled) != 1 || cancelled[0] != "known" || len(created) != 1 {
+		t.Fatalf("replace: placed %+v cancelled %v created %v", placed, cancelled, created)
+	}
+	if _, _, err := cl.ReplaceByClientID(ctx, "next", next); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("reusing the id: %v", err)
+	}
+
+	// the replacement must not go live unless the old order was cancelled
+	if _, _, err := cl.ReplaceByClientID(ctx, "gone", next); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("replacing a missing order: %v", err)
+	}
+	if _, old, err := cl.ReplaceByClientID(ctx, "filled", next); err == nil || len(old) != 1 || !old[0].Filled.Equal(MustDecimal("1")) {
+		t.Fatalf("replacing a filled order: %v %+v", err, old)
+	}
+	if len(created) != 1 {
+		t.Fatalf("replacements sent after failed cancels: %v", created)
+	}
+}
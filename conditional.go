@@ -0,0 +1,95 @@
+package etherealRest
+
+import (
+	"fmt"
+)
+
+// -------- BEGIN CONDITIONAL ORDERS -------- //
+
+// IsConditional reports whether the order waits for a stop price to trigger.
+func (o *Order) IsConditional() bool {
+	return o.StopType != nil
+}
+
+// StopLoss makes the order conditional, triggering once price crosses trigger
+// against the position. On a market builder this is a stop-market order, on a
+// limit builder a stop-limit order.
+func (b *OrderBuilder) StopLoss(trigger Decimal) *OrderBuilder {
+	return b.stop(STOP_LOSS, trigger)
+}
+
+// TakeProfit makes the order conditional, triggering once price crosses
+// trigger in the position's favour.
+func (b *OrderBuilder) TakeProfit(trigger Decimal) *OrderBuilder {
+	return b.stop(STOP_TAKE_PROFIT, trigger)
+}
+
+// TriggerOn selects the price a conditional order is triggered by.
+func (b *OrderBuilder) TriggerOn(source StopPriceType) *OrderBuilder {
+	b.order.StopPriceType = &source
+	return b
+}
+
+func (b *OrderBuilder) stop(t StopType, trigger Decimal) *OrderBuilder {
+	b.order.StopType = &t
+	b.order.StopPrice = trigger
+	return b
+}
+
+func (o *Order) validateStop() error {
+	switch {
+	case o.StopType == nil && (!o.StopPrice.IsZero() || o.StopPriceType != nil):
+		return fmt.Errorf("%w: stop price set without a stop type", ErrInvalidOrder)
+	case o.StopType != nil && o.StopPrice.Sign() <= 0:
+		return fmt.Errorf("%w: conditional orders require a positive stop price", ErrInvalidOrder)
+	case o.StopType != nil && o.PostOnly:
+		return fmt.Errorf("%w: postOnly is not allowed on conditional orders", ErrInvalidOrder)
+	}
+	return nil
+}
+
+// AttachStopLoss builds a reduce-only stop-market order that closes the
+// position once price crosses trigger against it.
+func (pos *Position) AttachStopLoss(p *Product, trigger Decimal) (*Order, error) {
+	return pos.attach(p, STOP_LOSS, trigger)
+}
+
+// AttachTakeProfit builds a reduce-only stop-market order that closes the
+// position once price crosses trigger in its favour.
+func (pos *Position) AttachTakeProfit(p *Product, trigger Decimal) (*Order, error) {
+	return pos.attach(p, STOP_TAKE_PROFIT, trigger)
+}
+
+func (pos *Position) attach(p *Product, t StopType, trigger Decimal) (*Order, error) {
+	if pos.ProductId != p.ID {
+		return nil, fmt.Errorf("%w: position is on product %s, not %s", ErrInvalidOrder, pos.ProductId, p.ID)
+	}
+	if pos.Size.IsZero() {
+		return nil, fmt.Errorf("%w: position %s is flat", ErrInvalidOrder, pos.Id)
+	}
+
+	// a long position is protected above and below by sells, a short one by buys
+	closingSide := SELL
+	if pos.Side == SELL {
+		closingSide = BUY
+	}
+	if err := pos.checkTrigger(t, trigger); err != nil {
+		return nil, err
+	}
+	return p.Market(closingSide).Qty(pos.Size.Abs()).ReduceOnly().stop(t, trigger).Build()
+}
+
+// checkTrigger rejects a stop loss beyond the liquidation price, which could
+// never trigger before the position is liquidated.
+func (pos *Position) checkTrigger(t StopType, trigger Decimal) error {
+	liq := pos.LiquidationPrice
+	if t != STOP_LOSS || liq.Sign() <= 0 {
+		return nil
+	}
+	if (pos.Side == BUY && trigger.LessThan(liq)) || (pos.Side == SELL && trigger.GreaterThan(liq)) {
+		return fmt.Errorf("%w: stop loss %s is beyond liquidation price %s", ErrInvalidOrder, trigger, liq)
+	}
+	return nil
+}
+
+// -------- END CONDITIONAL ORDERS -------- //
@@ -0,0 +1,107 @@
+package etherealRest
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOrderBuilder_stop(t *testing.T) {
+	p := testProduct()
+	o, err := p.Market(SELL).Qty(MustDecimal("1")).ReduceOnly().StopLoss(MustDecimal("1800")).TriggerOn(STOP_PRICE_LAST).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !o.IsConditional() || *o.StopType != STOP_LOSS || *o.StopPriceType != STOP_PRICE_LAST {
+		t.Fatalf("built: %+v", o)
+	}
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"stopPrice":"1800"`, `"stopType":1`, `"stopPriceType":1`} {
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("payload %s missing %s", b, want)
+		}
+	}
+
+	plain, _ := json.Marshal(p.NewOrder(ORDER_LIMIT, MustDecimal("1"), MustDecimal("100"), false, BUY, TIF_GTD))
+	if strings.Contains(string(plain), "stop") {
+		t.Fatalf("plain order carries stop fields: %s", plain)
+	}
+
+	one := MustDecimal("1")
+	cases := map[string]*OrderBuilder{
+		"zero trigger":     p.Market(SELL).Qty(one).StopLoss(Decimal{}),
+		"trigger only":     p.Market(SELL).Qty(one).TriggerOn(STOP_PRICE_MARK),
+		"postOnly stop":    p.Limit(SELL).Qty(one).Price(MustDecimal("100")).PostOnly().TakeProfit(MustDecimal("120")),
+		"negative trigger": p.Market(SELL).Qty(one).TakeProfit(MustDecimal("-1")),
+	}
+	for name, b := range cases {
+		if _, err := b.Build(); !errors.Is(err, ErrInvalidOrder) {
+			t.Fatalf("%s: expected ErrInvalidOrder, got %v", name, err)
+		}
+	}
+}
+
+func TestProductNormalize_stopPrice(t *testing.T) {
+	p := testProduct()
+	o, err := p.Market(SELL).Qty(MustDecimal("1")).StopLoss(MustDecimal("1800.2")).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// stop prices round in the policy's direction, like limit prices
+	if err := p.Normalize(o, RoundingPolicy{}); err != nil {
+		t.Fatal(err)
+	}
+	if !o.StopPrice.Equal(MustDecimal("1800.5")) {
+		t.Fatalf("passive sell stop price %s", o.StopPrice)
+	}
+	o.StopPrice = MustDecimal("1800.2")
+	if err := p.Normalize(o, RoundingPolicy{Price: PRICE_NEAREST}); err != nil {
+		t.Fatal(err)
+	}
+	if !o.StopPrice.Equal(MustDecimal("1800")) {
+		t.Fatalf("nearest stop price %s", o.StopPrice)
+	}
+
+	o.StopPrice = MustDecimal("1800.2")
+	var verr *ValidationError
+	if err := p.Normalize(o, RoundingPolicy{Strict: true}); !errors.As(err, &verr) || verr.Field != "stopPrice" {
+		t.Fatalf("expected stopPrice validation error, got %v", err)
+	}
+}
+
+func TestPositionAttach(t *testing.T) {
+	p := testProduct()
+	long := &Position{Id: "pos", ProductId: p.ID, Side: BUY, Size: MustDecimal("2"), LiquidationPrice: MustDecimal("1500")}
+
+	sl, err := long.AttachStopLoss(p, MustDecimal("1600"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sl.Side != SELL || !sl.ReduceOnly || sl.Type != ORDER_MARKET || !sl.Quantity.Equal(MustDecimal("2")) || *sl.StopType != STOP_LOSS {
+		t.Fatalf("stop loss: %+v", sl)
+	}
+
+	short := &Position{Id: "pos", ProductId: p.ID, Side: SELL, Size: MustDecimal("-2")}
+	tp, err := short.AttachTakeProfit(p, MustDecimal("1000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tp.Side != BUY || !tp.Quantity.Equal(MustDecimal("2")) || *tp.StopType != STOP_TAKE_PROFIT {
+		t.Fatalf("take profit: %+v", tp)
+	}
+
+	if _, err := long.AttachStopLoss(p, MustDecimal("1400")); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("stop beyond liquidation: %v", err)
+	}
+	if _, err := (&Position{ProductId: "other", Size: MustDecimal("1")}).AttachStopLoss(p, MustDecimal("1")); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("wrong product: %v", err)
+	}
+	if _, err := (&Position{ProductId: p.ID}).AttachTakeProfit(p, MustDecimal("1")); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("flat position: %v", err)
+	}
+}
@@ -0,0 +1,306 @@
+package etherealRest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// -------- BEGIN DECIMAL -------- //
+
+const decimalPlaces = 9
+
+var decimalUnit = big.NewInt(1_000_000_000)
+
+// Decimal is a fixed-point number with 9 decimal places, the same 1e9 scaling
+// the venue uses on chain and in EIP-712 messages, so a value is signed
+// exactly as it was computed. The zero value is 0. Decimals are immutable;
+// every operation returns a new value.
+type Decimal struct {
+	n *big.Int // value * 1e9, nil means zero
+}
+
+type RoundingMode int
+
+const (
+	ROUND_DOWN      RoundingMode = iota // toward zero
+	ROUND_UP                            // away from zero
+	ROUND_FLOOR                         // toward negative infinity
+	ROUND_CEIL                          // toward positive infinity
+	ROUND_HALF_UP                       // to nearest, ties away from zero
+	ROUND_HALF_EVEN                     // to nearest, ties to even
+)
+
+// ParseDecimal parses a decimal string such as "1.5", "-0.000000001" or
+// "2e3", with an exponent of at most 100. It fails rather than round when s
+// has more than 9 decimal places.
+func ParseDecimal(s string) (Decimal, error) {
+	r, err := parseRat(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	r.Mul(r, new(big.Rat).SetInt(decimalUnit))
+	if !r.IsInt() {
+		return Decimal{}, fmt.Errorf("decimal %q has more than %d decimal places", s, decimalPlaces)
+	}
+	return Decimal{n: new(big.Int).Set(r.Num())}, nil
+}
+
+// ParseDecimalRound parses s, rounding to 9 decimal places with mode.
+func ParseDecimalRound(s string, mode RoundingMode) (Decimal, error) {
+	r, err := parseRat(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	r.Mul(r, new(big.Rat).SetInt(decimalUnit))
+	return Decimal{n: roundQuo(r.Num(), r.Denom(), mode)}, nil
+}
+
+// MustDecimal is like ParseDecimal but panics on error. Intended for constants.
+func MustDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromInt returns the integer i as a Decimal.
+func DecimalFromInt(i int64) Decimal {
+	n := big.NewInt(i)
+	return Decimal{n: n.Mul(n, decimalUnit)}
+}
+
+// DecimalFromFloat converts f using its shortest decimal representation,
+// rounded half-even to 9 decimal places.
+func DecimalFromFloat(f float64) (Decimal, error) {
+	return ParseDecimalRound(strconv.FormatFloat(f, 'g', -1, 64), ROUND_HALF_EVEN)
+}
+
+// DecimalFromScaled returns the Decimal whose 1e9-scaled value is n.
+func DecimalFromScaled(n *big.Int) Decimal {
+	return Decimal{n: new(big.Int).Set(n)}
+}
+
+// decimalSyntax is the plain decimal notation accepted by ParseDecimal. It
+// keeps out the base prefixes, fractions and underscores big.Rat allows.
+var decimalSyntax = regexp.MustCompile(`^[+-]?(?:[0-9]+(?:\.[0-9]+)?|\.[0-9]+)(?:[eE]([+-]?[0-9]+))?$`)
+
+// maxDecimalExponent bounds the exponent so input like "1e999999999" cannot
+// make big.Rat allocate a huge integer.
+const maxDecimalExponent = 100
+
+func parseRat(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+	m := decimalSyntax.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("bad decimal %q", s)
+	}
+	if m[1] != "" {
+		if exp, err := strconv.Atoi(m[1]); err != nil || exp < -maxDecimalExponent || exp > maxDecimalExponent {
+			return nil, fmt.Errorf("decimal %q exponent out of range", s)
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("bad decimal %q", s)
+	}
+	return r, nil
+}
+
+func (d Decimal) int() *big.Int {
+	if d.n == nil {
+		return new(big.Int)
+	}
+	return d.n
+}
+
+// Scaled returns a copy of the value multiplied by 1e9, as encoded in EIP-712 messages.
+func (d Decimal) Scaled() *big.Int {
+	return new(big.Int).Set(d.int())
+}
+
+func (d Decimal) String() string {
+	n := d.int()
+	neg := n.Sign() < 0
+	digits := new(big.Int).Abs(n).String()
+	if len(digits) <= decimalPlaces {
+		digits = strings.Repeat("0", decimalPlaces-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-decimalPlaces], strings.TrimRight(digits[len(digits)-decimalPlaces:], "0")
+	s := whole
+	if frac != "" {
+		s += "." + frac
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Float64 returns the nearest float64; use only for display or statistics.
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.int(), decimalUnit).Float64()
+	return f
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	return Decimal{n: new(big.Int).Add(d.int(), o.int())}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	return Decimal{n: new(big.Int).Sub(d.int(), o.int())}
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{n: new(big.Int).Neg(d.int())}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{n: new(big.Int).Abs(d.int())}
+}
+
+// Mul returns d*o rounded half-even to 9 decimal places.
+func (d Decimal) Mul(o Decimal) Decimal {
+	return d.MulRound(o, ROUND_HALF_EVEN)
+}
+
+// MulRound returns d*o rounded to 9 decimal places with mode.
+func (d Decimal) MulRound(o Decimal, mode RoundingMode) Decimal {
+	p := new(big.Int).Mul(d.int(), o.int())
+	return Decimal{n: roundQuo(p, decimalUnit, mode)}
+}
+
+// Div returns d/o rounded to 9 decimal places with mode. It panics if o is zero.
+func (d Decimal) Div(o Decimal, mode RoundingMode) Decimal {
+	if o.IsZero() {
+		panic("etherealRest: decimal division by zero")
+	}
+	p := new(big.Int).Mul(d.int(), decimalUnit)
+	return Decimal{n: roundQuo(p, o.int(), mode)}
+}
+
+// Round rounds d to places decimal places (0..9) with mode.
+func (d Decimal) Round(places int, mode RoundingMode) Decimal {
+	if places >= decimalPlaces {
+		return d
+	}
+	step := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimalPlaces-max(places, 0))), nil)
+	return d.roundToMultiple(step, mode)
+}
+
+// RoundToStep rounds d to a multiple of step, such as a tick or lot size.
+// A non-positive step returns d unchanged.
+func (d Decimal) RoundToStep(step Decimal, mode RoundingMode) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	return d.roundToMultiple(step.int(), mode)
+}
+
+// IsMultipleOf reports whether d is an exact multiple of step.
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.Sign() <= 0 {
+		return true
+	}
+	return new(big.Int).Rem(d.int(), step.int()).Sign() == 0
+}
+
+func (d Decimal) roundToMultiple(step *big.Int, mode RoundingMode) Decimal {
+	q := roundQuo(d.int(), step, mode)
+	return Decimal{n: q.Mul(q, step)}
+}
+
+func (d Decimal) Cmp(o Decimal) int {
+	return d.int().Cmp(o.int())
+}
+
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+func (d Decimal) LessThan(o Decimal) bool {
+	return d.Cmp(o) < 0
+}
+
+func (d Decimal) GreaterThan(o Decimal) bool {
+	return d.Cmp(o) > 0
+}
+
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON accepts a JSON string or number. Empty strings and null decode
+// to zero. Venue values are 1e9 scaled, so anything finer is rounded half-even.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+	v, err := ParseDecimalRound(s, ROUND_HALF_EVEN)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// roundQuo returns num/den rounded to an integer with mode. den must not be zero.
+func roundQuo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// sign of the exact quotient
+	sign := num.Sign() * den.Sign()
+
+	var away bool
+	switch mode {
+	case ROUND_DOWN:
+		away = false
+	case ROUND_UP:
+		away = true
+	case ROUND_FLOOR:
+		away = sign < 0
+	case ROUND_CEIL:
+		away = sign > 0
+	case ROUND_HALF_UP, ROUND_HALF_EVEN:
+		twice := new(big.Int).Abs(r)
+		twice.Lsh(twice, 1)
+		switch twice.Cmp(new(big.Int).Abs(den)) {
+		case 1:
+			away = true
+		case 0:
+			away = mode == ROUND_HALF_UP || q.Bit(0) == 1
+		}
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return q
+}
+
+// -------- END DECIMAL -------- //
@@ -0,0 +1,133 @@
+package etherealRest
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	cases := []struct {
+		in, want string
+		scaled   string
+	}{
+		{"1", "1", "1000000000"},
+		{"0.000000001", "0.000000001", "1"},
+		{"-1.50", "-1.5", "-1500000000"},
+		{"3000.000000000", "3000", "3000000000000"},
+		{"2e3", "2000", "2000000000000"},
+		{"+15E-1", "1.5", "1500000000"},
+		{"5e-9", "0.000000005", "5"},
+		{".25", "0.25", "250000000"},
+		{"123456789012345.123456789", "123456789012345.123456789", "123456789012345123456789"},
+	}
+	for _, tc := range cases {
+		d, err := ParseDecimal(tc.in)
+		if err != nil {
+			t.Fatalf("ParseDecimal(%q): %v", tc.in, err)
+		}
+		if d.String() != tc.want || d.Scaled().String() != tc.scaled {
+			t.Fatalf("ParseDecimal(%q) = %s (%s) want %s (%s)", tc.in, d, d.Scaled(), tc.want, tc.scaled)
+		}
+	}
+	for _, bad := range []string{
+		"", "abc", "1/3", "0.0000000001", "1_000", "1.", ".", "+", "1e", "--1",
+		"0x10", "0X10", "0b1", "0o7", "0x1p4", "1e101", "1e-101", "1e999999999", "1e99999999999999999999",
+	} {
+		if _, err := ParseDecimal(bad); err == nil {
+			t.Fatalf("ParseDecimal(%q): expected error", bad)
+		}
+	}
+}
+
+func TestDecimal_rounding(t *testing.T) {
+	cases := []struct {
+		in   string
+		mode RoundingMode
+		want string
+	}{
+		{"0.0000000015", ROUND_DOWN, "0.000000001"},
+		{"0.0000000015", ROUND_UP, "0.000000002"},
+		{"0.0000000015", ROUND_HALF_EVEN, "0.000000002"},
+		{"0.0000000025", ROUND_HALF_EVEN, "0.000000002"},
+		{"0.0000000025", ROUND_HALF_UP, "0.000000003"},
+		{"-0.0000000015", ROUND_FLOOR, "-0.000000002"},
+		{"-0.0000000015", ROUND_CEIL, "-0.000000001"},
+		{"-0.0000000015", ROUND_DOWN, "-0.000000001"},
+	}
+	for _, tc := range cases {
+		d, err := ParseDecimalRound(tc.in, tc.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.String() != tc.want {
+			t.Fatalf("ParseDecimalRound(%q, %d) = %s want %s", tc.in, tc.mode, d, tc.want)
+		}
+	}
+
+	tick := MustDecimal("0.5")
+	px := MustDecimal("100.3")
+	if got := px.RoundToStep(tick, ROUND_FLOOR).String(); got != "100" {
+		t.Fatalf("floor to tick: %s", got)
+	}
+	if got := px.RoundToStep(tick, ROUND_CEIL).String(); got != "100.5" {
+		t.Fatalf("ceil to tick: %s", got)
+	}
+	if px.IsMultipleOf(tick) || !MustDecimal("100.5").IsMultipleOf(tick) {
+		t.Fatal("IsMultipleOf")
+	}
+	if got := MustDecimal("1.23456").Round(2, ROUND_HALF_UP).String(); got != "1.23" {
+		t.Fatalf("Round: %s", got)
+	}
+}
+
+func TestDecimal_arithmetic(t *testing.T) {
+	a, b := MustDecimal("1.5"), MustDecimal("0.000000003")
+	if got := a.Add(b).String(); got != "1.500000003" {
+		t.Fatalf("Add: %s", got)
+	}
+	if got := b.Sub(a).String(); got != "-1.499999997" {
+		t.Fatalf("Sub: %s", got)
+	}
+	if got := a.Mul(MustDecimal("2000.25")).String(); got != "3000.375" {
+		t.Fatalf("Mul: %s", got)
+	}
+	if got := DecimalFromInt(1).Div(DecimalFromInt(3), ROUND_HALF_EVEN).String(); got != "0.333333333" {
+		t.Fatalf("Div: %s", got)
+	}
+	if !a.GreaterThan(b) || !b.LessThan(a) || a.Cmp(MustDecimal("1.50")) != 0 || !(Decimal{}).IsZero() {
+		t.Fatal("comparisons")
+	}
+	if d, err := DecimalFromFloat(0.1); err != nil || d.String() != "0.1" {
+		t.Fatalf("DecimalFromFloat: %s %v", d, err)
+	}
+	if a.Neg().Abs().String() != "1.5" {
+		t.Fatal("Neg/Abs")
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var v struct {
+		A Decimal `json:"a"`
+		B Decimal `json:"b"`
+		C Decimal `json:"c"`
+		D Decimal `json:"d"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"1.25","b":0.5,"c":"","d":null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A.String() != "1.25" || v.B.String() != "0.5" || !v.C.IsZero() || !v.D.IsZero() {
+		t.Fatalf("%+v", v)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"a":"1.25","b":"0.5","c":"0","d":"0"}` {
+		t.Fatalf("marshal: %s", b)
+	}
+	for _, bad := range []string{`{"a":"nope"}`, `{"a":1e999999999}`, `{"a":"0x10"}`} {
+		if err := json.Unmarshal([]byte(bad), &v); err == nil {
+			t.Fatalf("%s: expected error", bad)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package etherealRest
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 )
 
 type OrderClient interface {
@@ -11,27 +12,77 @@ type OrderClient interface {
 }
 
 type OrderCreated struct { // TODO: missed one
-	Id     string `json:"id"`
-	Cloid  string `json:"clientOrderId"`
-	Filled string `json:"filled"`
-	Result string `json:"result"`
+	Id     string  `json:"id"`
+	Cloid  string  `json:"clientOrderId"`
+	Filled Decimal `json:"filled"`
+	Result string  `json:"result"`
 }
 
-func (o *Order) Send(ctx context.Context, cl OrderClient, signer *Signer) (OrderCreated, error) {
-	var err error
-	var created OrderCreated
+// orders are only safe to resubmit when the venue can dedupe them by client order id
+func (o *Order) idempotent() bool {
+	return o.ClientOrderID != ""
+}
 
-	o.Build(cl)
+// cancelling an already cancelled order has no further effect
+func (o *OrderCancel) idempotent() bool {
+	return true
+}
 
-	sig, err := Sign(o, "TradeOrder", signer)
-	if err != nil {
-		return created, err
+// initializer is implemented by clients that can defer their setup until first use.
+type initializer interface {
+	Init(ctx context.Context) error
+}
+
+// sendSigned builds, signs and posts msg. When cl retries, every attempt
+// rebuilds the message so it is re-signed with a fresh nonce.
+func sendSigned(ctx context.Context, cl OrderClient, signer CanSign, primaryType, path string, msg Signable) ([]byte, error) {
+	if signer == nil || signer.GetDigestSigner() == nil {
+		return nil, ErrReadOnly
+	}
+	if n, ok := msg.(normalizer); ok {
+		if err := n.normalize(roundingPolicyOf(cl)); err != nil {
+			return nil, err
+		}
+	}
+	if i, ok := cl.(initializer); ok {
+		if err := i.Init(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if s, ok := cl.(schemaChecker); ok {
+		if err := s.checkSchema(primaryType); err != nil {
+			return nil, err
+		}
+	}
+	// the first build may assign a client order id, which decides whether retries are safe
+	if err := msg.Build(cl); err != nil {
+		return nil, err
 	}
+	attempt := func(n int) ([]byte, error) {
+		if n > 0 {
+			if err := msg.Build(cl); err != nil {
+				return nil, err
+			}
+		}
+		sig, err := SignContext(ctx, msg, primaryType, signer)
+		if err != nil {
+			return nil, err
+		}
+		return cl.Do(ctx, http.MethodPost, path, SignedMessage[Signable]{
+			Data:      msg,
+			Signature: sig,
+		})
+	}
+	if r, ok := cl.(retrier); ok {
+		return r.retry(ctx, isIdempotent(msg), attempt)
+	}
+	return attempt(0)
+}
+
+func (o *Order) Send(ctx context.Context, cl OrderClient, signer *Signer) (OrderCreated, error) {
+	var created OrderCreated
 
-	resp, err := cl.Do(ctx, "POST", "/v1/order", SignedMessage[*Order]{
-		Data:      o,
-		Signature: sig,
-	})
+	resp, err := sendSigned(ctx, cl, signer, "TradeOrder", "/v1/order", o)
 	if err != nil {
 		return created, err
 	}
@@ -46,17 +97,7 @@ func (o *Order) Send(ctx context.Context, cl OrderClient, signer *Signer) (Order
 func (o *OrderCancel) Send(ctx context.Context, cl OrderClient, signer *Signer) ([]*OrderCancelled, error) {
 	var cancelled Response[[]*OrderCancelled]
 
-	o.Build(cl)
-
-	sig, err := Sign(o, "CancelOrder", signer)
-	if err != nil {
-		return cancelled.Data, err
-	}
-
-	resp, err := cl.Do(ctx, "POST", "/v1/order/cancel", SignedMessage[*OrderCancel]{
-		Data:      o,
-		Signature: sig,
-	})
+	resp, err := sendSigned(ctx, cl, signer, "CancelOrder", "/v1/order/cancel", o)
 	if err != nil {
 		return cancelled.Data, err
 	}
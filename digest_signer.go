@@ -0,0 +1,97 @@
+package etherealRest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// -------- BEGIN DIGEST SIGNERS -------- //
+
+// DigestSigner signs 32-byte EIP-712 digests for an address. It is all Sign
+// needs from a key, so the key itself can stay in a KMS, HSM or separate
+// signing process.
+type DigestSigner interface {
+	Address() common.Address
+	// SignDigest returns a 65-byte [R || S || V] secp256k1 signature of
+	// digest. V may be 0/1 or 27/28.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// KeySigner signs with a private key held in memory until Close.
+type KeySigner struct {
+	mu      sync.RWMutex
+	pk      *ecdsa.PrivateKey
+	address common.Address
+}
+
+func NewKeySigner(pk *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{pk: pk, address: crypto.PubkeyToAddress(pk.PublicKey)}
+}
+
+func (k *KeySigner) Address() common.Address {
+	return k.address
+}
+
+func (k *KeySigner) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.pk == nil {
+		return nil, ErrSignerClosed
+	}
+	return crypto.Sign(digest, k.pk)
+}
+
+// Close zeroes the key; the signer cannot sign afterwards.
+func (k *KeySigner) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	zeroKey(k.pk)
+	k.pk = nil
+	return nil
+}
+
+func (k *KeySigner) privateKey() *ecdsa.PrivateKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.pk
+}
+
+// zeroKey overwrites the private scalar of pk.
+func zeroKey(pk *ecdsa.PrivateKey) {
+	if pk == nil || pk.D == nil {
+		return
+	}
+	b := pk.D.Bits()
+	for i := range b {
+		b[i] = 0
+	}
+	pk.D.SetInt64(0)
+}
+
+// signDigest signs digest with s and normalizes the result to the venue's
+// [R || S || V] form with V of 27 or 28.
+func signDigest(ctx context.Context, s DigestSigner, digest []byte) ([]byte, error) {
+	sig, err := s.SignDigest(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("digest signer returned a %d byte signature, want 65", len(sig))
+	}
+	sig = append([]byte(nil), sig...)
+	if sig[64] < 27 {
+		sig[64] += 27 // recovery byte fix  |  much love to _0xmer :)
+	}
+	if sig[64] != 27 && sig[64] != 28 {
+		return nil, errors.New("digest signer returned an invalid recovery id")
+	}
+	return sig, nil
+}
+
+// -------- END DIGEST SIGNERS -------- //
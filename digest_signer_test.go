@@ -0,0 +1,93 @@
+package etherealRest
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testKeyHex = "0bb5d63b84421e1268dda020818ae30cf26e7f10e321fb820a8aa69216dea92a"
+
+func writeTestKeystore(t *testing.T, passphrase string) string {
+	t.Helper()
+	pk := mustECDSA(t, testKeyHex)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Address:    crypto.PubkeyToAddress(pk.PublicKey),
+		PrivateKey: pk,
+	}, passphrase, keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, keyJSON, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDigestSigners_agree(t *testing.T) {
+	ctx := context.Background()
+	local := NewKeySigner(mustECDSA(t, testKeyHex))
+
+	ks, err := LoadKeystoreKey(writeTestKeystore(t, "hunter2"), "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(NewSignerHandler(local))
+	defer srv.Close()
+	remote, err := NewRemoteSigner(ctx, srv.URL, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order := &Order{
+		Subaccount: "0x123456789abcde00000000000000000000000000000000000000000000000000",
+		Quantity:   MustDecimal("1"),
+		Price:      MustDecimal("3000"),
+		Nonce:      "1764897077655477722",
+		SignedAt:   1764897077,
+	}
+	var sigs []string
+	for _, key := range []DigestSigner{local, ks, remote} {
+		if key.Address() != local.Address() {
+			t.Fatalf("%T address %s, want %s", key, key.Address(), local.Address())
+		}
+		s := NewDigestSigner(key)
+		cl := &Client{account: s}
+		if _, err := cl.useTypes(testTypedData(t)); err != nil {
+			t.Fatal(err)
+		}
+		order.Sender = s.Address
+		sig, err := SignContext(ctx, order, "TradeOrder", s)
+		if err != nil {
+			t.Fatalf("%T: %v", key, err)
+		}
+		sigs = append(sigs, sig)
+	}
+	if sigs[0] != sigs[1] || sigs[0] != sigs[2] {
+		t.Fatalf("signatures differ: %v", sigs)
+	}
+	if v := sigs[0][len(sigs[0])-2:]; v != "1b" && v != "1c" {
+		t.Fatalf("recovery byte %s, want 1b or 1c", v)
+	}
+}
+
+func TestLoadKeystore_wrongPassphrase(t *testing.T) {
+	if _, err := LoadKeystore(writeTestKeystore(t, "right"), "wrong"); !errors.Is(err, keystore.ErrDecrypt) {
+		t.Fatalf("expected ErrDecrypt, got %v", err)
+	}
+}
+
+func TestSign_readOnly(t *testing.T) {
+	order := &Order{Quantity: MustDecimal("1"), Price: MustDecimal("1")}
+	if _, err := Sign(order, "TradeOrder", &Signer{}); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+}
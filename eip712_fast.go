@@ -0,0 +1,246 @@
+package etherealRest
+
+import (
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// -------- BEGIN COMPILED ENCODERS -------- //
+
+// compiledLayout is an EIP-712 struct whose encoding is written out by hand
+// for a message type, skipping the reflection and big.Int allocations of
+// TypedData.HashStruct.
+type compiledLayout struct {
+	fields   []abi.Type
+	typeHash [32]byte
+}
+
+func newCompiledLayout(primaryType, schema string) *compiledLayout {
+	fields := mustParseTypeSchema(schema)
+	types := abi.TypedData{Types: abi.Types{primaryType: fields}}
+	l := &compiledLayout{fields: fields}
+	copy(l.typeHash[:], types.TypeHash(primaryType))
+	return l
+}
+
+var (
+	tradeOrderLayout = newCompiledLayout("TradeOrder",
+		"address sender, bytes32 subaccount, uint128 quantity, uint128 price, bool reduceOnly, uint8 side, uint8 engineType, uint32 productId, uint64 nonce, uint64 signedAt")
+	cancelOrderLayout = newCompiledLayout("CancelOrder",
+		"address sender, bytes32 subaccount, uint64 nonce")
+)
+
+// matches reports whether the venue's schema for primaryType is exactly the
+// compiled one: same field names and types in the same order.
+func (l *compiledLayout) matches(types *abi.TypedData, primaryType string) bool {
+	if types == nil {
+		return false
+	}
+	fields := types.Types[primaryType]
+	if len(fields) != len(l.fields) {
+		return false
+	}
+	for i, f := range fields {
+		if f.Name != l.fields[i].Name || f.Type != l.fields[i].Type {
+			return false
+		}
+	}
+	return true
+}
+
+// compiledEncoder is implemented by messages with a compiled layout.
+type compiledEncoder interface {
+	// encodeCompiled writes the EIP-712 encoding of the message as
+	// primaryType into buf and returns it. ok is false when there is no
+	// compiled layout for primaryType, it differs from the schema in types,
+	// or a value is one the generic path should judge (and usually reject).
+	encodeCompiled(types *abi.TypedData, primaryType string, buf []byte) (enc []byte, ok bool)
+}
+
+// encodeScratch is the hasher and buffer reused between compiled encodings.
+type encodeScratch struct {
+	h   crypto.KeccakState
+	buf []byte
+}
+
+var (
+	encodeScratchPool = sync.Pool{New: func() any {
+		return &encodeScratch{h: crypto.NewKeccakState(), buf: make([]byte, 0, 11*32)}
+	}}
+	eip712Prefix = []byte{0x19, 0x01}
+)
+
+func (s *encodeScratch) keccak(out []byte, parts ...[]byte) {
+	s.h.Reset()
+	for _, p := range parts {
+		s.h.Write(p)
+	}
+	s.h.Read(out)
+}
+
+// messageDigest returns the EIP-712 digest of message as primaryType, through
+// its compiled encoder when the schema matches and TypedData.HashStruct
+// otherwise. Both produce identical bytes.
+func messageDigest(types *abi.TypedData, domainHash []byte, message Signable, primaryType string) ([]byte, error) {
+	if c, ok := message.(compiledEncoder); ok {
+		s := encodeScratchPool.Get().(*encodeScratch)
+		defer encodeScratchPool.Put(s)
+		if enc, ok := c.encodeCompiled(types, primaryType, s.buf[:0]); ok {
+			var structHash [32]byte
+			digest := make([]byte, 32)
+			s.keccak(structHash[:], enc)
+			s.keccak(digest, eip712Prefix, domainHash, structHash[:])
+			return digest, nil
+		}
+	}
+
+	msg, err := message.ToMessage()
+	if err != nil {
+		return nil, err
+	}
+	messageHash, err := types.HashStruct(primaryType, msg)
+	if err != nil {
+		return nil, err
+	}
+	return MakeFullHash(domainHash, messageHash), nil
+}
+
+func (o *Order) encodeCompiled(types *abi.TypedData, primaryType string, buf []byte) ([]byte, bool) {
+	if primaryType != "TradeOrder" || !tradeOrderLayout.matches(types, primaryType) {
+		return nil, false
+	}
+	nonce, err := strconv.ParseUint(o.Nonce, 10, 64)
+	if err != nil ||
+		o.Side < 0 || o.Side > math.MaxUint8 ||
+		o.EngineType < 0 || o.EngineType > math.MaxUint8 ||
+		o.OnchainID < 0 || o.OnchainID > math.MaxUint32 ||
+		o.SignedAt < 0 {
+		return nil, false
+	}
+
+	buf = append(buf, tradeOrderLayout.typeHash[:]...)
+	buf, ok := appendAddress(buf, o.Sender)
+	if !ok {
+		return nil, false
+	}
+	if buf, ok = appendBytes32(buf, o.Subaccount); !ok {
+		return nil, false
+	}
+	if buf, ok = appendUint128(buf, o.Quantity); !ok {
+		return nil, false
+	}
+	if buf, ok = appendUint128(buf, o.Price); !ok {
+		return nil, false
+	}
+	var reduceOnly uint64
+	if o.ReduceOnly {
+		reduceOnly = 1
+	}
+	buf = appendUint64(buf, reduceOnly)
+	buf = appendUint64(buf, uint64(o.Side))
+	buf = appendUint64(buf, uint64(o.EngineType))
+	buf = appendUint64(buf, uint64(o.OnchainID))
+	buf = appendUint64(buf, nonce)
+	buf = appendUint64(buf, uint64(o.SignedAt))
+	return buf, true
+}
+
+func (o *OrderCancel) encodeCompiled(types *abi.TypedData, primaryType string, buf []byte) ([]byte, bool) {
+	if primaryType != "CancelOrder" || !cancelOrderLayout.matches(types, primaryType) {
+		return nil, false
+	}
+	nonce, err := strconv.ParseUint(o.Nonce, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	buf = append(buf, cancelOrderLayout.typeHash[:]...)
+	buf, ok := appendAddress(buf, o.Sender)
+	if !ok {
+		return nil, false
+	}
+	if buf, ok = appendBytes32(buf, o.Subaccount); !ok {
+		return nil, false
+	}
+	buf = appendUint64(buf, nonce)
+	return buf, true
+}
+
+// appendAddress appends a hex address left-padded to 32 bytes. Like
+// common.IsHexAddress, the 0x prefix is optional.
+func appendAddress(buf []byte, s string) ([]byte, bool) {
+	s = trimHexPrefix(s)
+	if len(s) != 40 {
+		return buf, false
+	}
+	buf = append(buf, make([]byte, 12)...)
+	return appendHex(buf, s)
+}
+
+// appendBytes32 appends a 0x-prefixed 32-byte hex string, as hexutil.Decode
+// would parse it.
+func appendBytes32(buf []byte, s string) ([]byte, bool) {
+	if len(s) != 66 || trimHexPrefix(s) == s {
+		return buf, false
+	}
+	return appendHex(buf, s[2:])
+}
+
+// appendUint128 appends d scaled by 1e9, failing when it is negative or does
+// not fit in 128 bits.
+func appendUint128(buf []byte, d Decimal) ([]byte, bool) {
+	var word [32]byte
+	if d.n != nil {
+		if d.n.Sign() < 0 || d.n.BitLen() > 128 {
+			return buf, false
+		}
+		d.n.FillBytes(word[:])
+	}
+	return append(buf, word[:]...), true
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var word [32]byte
+	for i := 31; v > 0; i-- {
+		word[i] = byte(v)
+		v >>= 8
+	}
+	return append(buf, word[:]...)
+}
+
+func trimHexPrefix(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
+	}
+	return s
+}
+
+func appendHex(buf []byte, s string) ([]byte, bool) {
+	for i := 0; i < len(s); i += 2 {
+		hi, ok1 := fromHexChar(s[i])
+		lo, ok2 := fromHexChar(s[i+1])
+		if !ok1 || !ok2 {
+			return buf, false
+		}
+		buf = append(buf, hi<<4|lo)
+	}
+	return buf, true
+}
+
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// -------- END COMPILED ENCODERS -------- //
@@ -0,0 +1,183 @@
+package etherealRest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// genericDigest is the digest as computed before compiled encoders existed.
+func genericDigest(types *abi.TypedData, domainHash []byte, msg Signable, primaryType string) ([]byte, error) {
+	m, err := msg.ToMessage()
+	if err != nil {
+		return nil, err
+	}
+	h, err := types.HashStruct(primaryType, m)
+	if err != nil {
+		return nil, err
+	}
+	return MakeFullHash(domainHash, h), nil
+}
+
+func testDomainHash(t testing.TB, types *abi.TypedData) []byte {
+	t.Helper()
+	h, err := types.HashStruct("EIP712Domain", types.Domain.Map())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func benchOrder() *Order {
+	return &Order{
+		Sender:     "0xdeadbeef00000000000000000000000000000000",
+		Subaccount: "0x7072696d61727900000000000000000000000000000000000000000000000000",
+		Quantity:   MustDecimal("1.5"),
+		Price:      MustDecimal("3150.25"),
+		Side:       SELL,
+		EngineType: PERPETUAL,
+		OnchainID:  2,
+		Nonce:      "1764897077655477722",
+		SignedAt:   1764897077,
+	}
+}
+
+func TestCompiledEncoders_matchGeneric(t *testing.T) {
+	types := testTypedData(t)
+	domainHash := testDomainHash(t, types)
+
+	maxU128 := MustDecimal("340282366920938463463.374607431") // 2^128-1 scaled by 1e9
+	orders := map[string]func(o *Order){
+		"plain": func(o *Order) {},
+		"zero values": func(o *Order) {
+			o.Quantity, o.Price, o.Side, o.OnchainID, o.SignedAt, o.Nonce = Decimal{}, Decimal{}, BUY, 0, 0, "0"
+		},
+		"reduce only": func(o *Order) { o.ReduceOnly = true },
+		"max values": func(o *Order) {
+			o.Quantity, o.Price, o.OnchainID, o.Nonce = maxU128, maxU128, 1<<32-1, "18446744073709551615"
+		},
+		"checksummed":   func(o *Order) { o.Sender = "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF" },
+		"no 0x sender":  func(o *Order) { o.Sender = strings.TrimPrefix(o.Sender, "0x") },
+		"upper hex":     func(o *Order) { o.Subaccount = "0X" + strings.ToUpper(o.Subaccount[2:]) },
+		"hex nonce":     func(o *Order) { o.Nonce = "0x1f" },
+		"negative qty":  func(o *Order) { o.Quantity = MustDecimal("-1") },
+		"qty too large": func(o *Order) { o.Quantity = maxU128.Add(MustDecimal("0.000000001")) },
+		"nonce too big": func(o *Order) { o.Nonce = "18446744073709551616" },
+		"bad sender":    func(o *Order) { o.Sender = "0xnothex" },
+		"short sub":     func(o *Order) { o.Subaccount = "0x01" },
+		"bad side":      func(o *Order) { o.Side = 256 },
+	}
+	for name, mutate := range orders {
+		o := benchOrder()
+		mutate(o)
+		compareDigests(t, name, types, domainHash, o, "TradeOrder")
+	}
+
+	cancels := map[string]func(c *OrderCancel){
+		"plain":      func(c *OrderCancel) {},
+		"zero nonce": func(c *OrderCancel) { c.Nonce = "0" },
+		"bad nonce":  func(c *OrderCancel) { c.Nonce = "x" },
+	}
+	for name, mutate := range cancels {
+		c := NewCancel("oid")
+		c.Sender, c.Subaccount, c.Nonce = benchOrder().Sender, benchOrder().Subaccount, "42"
+		mutate(c)
+		compareDigests(t, "cancel "+name, types, domainHash, c, "CancelOrder")
+	}
+
+	// wrong primary types must still fail the way the generic path does
+	compareDigests(t, "order as cancel", types, domainHash, benchOrder(), "CancelOrder")
+}
+
+func compareDigests(t *testing.T, name string, types *abi.TypedData, domainHash []byte, msg Signable, primaryType string) {
+	t.Helper()
+	got, gotErr := messageDigest(types, domainHash, msg, primaryType)
+	want, wantErr := genericDigest(types, domainHash, msg, primaryType)
+	if (gotErr != nil) != (wantErr != nil) {
+		t.Fatalf("%s: error %v, generic error %v", name, gotErr, wantErr)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s: digest %x, generic %x", name, got, want)
+	}
+}
+
+func TestCompiledEncoders_schemaMismatchFallsBack(t *testing.T) {
+	types := testTypedData(t)
+	domainHash := testDomainHash(t, types)
+	o := benchOrder()
+	if _, ok := o.encodeCompiled(types, "TradeOrder", nil); !ok {
+		t.Fatal("compiled encoder not used for the venue schema")
+	}
+
+	// a venue schema with swapped fields must not use the compiled layout
+	fields := types.Types["TradeOrder"]
+	fields[2], fields[3] = fields[3], fields[2]
+	if _, ok := o.encodeCompiled(types, "TradeOrder", nil); ok {
+		t.Fatal("compiled encoder used for a different schema")
+	}
+	compareDigests(t, "swapped", types, domainHash, o, "TradeOrder")
+
+	types.Types["TradeOrder"] = append(fields[:0:0], fields...)[:9]
+	compareDigests(t, "truncated", types, domainHash, o, "TradeOrder")
+}
+
+func BenchmarkTradeOrderDigest(b *testing.B) {
+	types := testTypedData(b)
+	domainHash := testDomainHash(b, types)
+	o := benchOrder()
+	b.Run("compiled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := messageDigest(types, domainHash, o, "TradeOrder"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := genericDigest(types, domainHash, o, "TradeOrder"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkCancelOrderDigest(b *testing.B) {
+	types := testTypedData(b)
+	domainHash := testDomainHash(b, types)
+	c := NewCancel("oid")
+	c.Sender, c.Subaccount, c.Nonce = benchOrder().Sender, benchOrder().Subaccount, "42"
+	b.Run("compiled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := messageDigest(types, domainHash, c, "CancelOrder"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := genericDigest(types, domainHash, c, "CancelOrder"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSignTradeOrder(b *testing.B) {
+	s := NewSigner(mustECDSA(b, testKeyHex))
+	types := testTypedData(b)
+	s.SetTypes(types)
+	s.SetDomainHash(testDomainHash(b, types))
+	o := benchOrder()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := Sign(o, "TradeOrder", s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
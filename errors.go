@@ -0,0 +1,137 @@
+package etherealRest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors mapped from venue error payloads. Match them with errors.Is
+// against any error returned by the client; the underlying *APIError is
+// available through errors.As.
+var (
+	ErrRateLimited        = errors.New("ethereal: rate limited")
+	ErrInsufficientMargin = errors.New("ethereal: insufficient margin")
+	ErrMaintenance        = errors.New("ethereal: venue under maintenance")
+	ErrInvalidSignature   = errors.New("ethereal: invalid signature")
+)
+
+// Client-side errors.
+var (
+	ErrReadOnly      = errors.New("ethereal: read-only client cannot sign")
+	ErrNoSubaccount  = errors.New("ethereal: no subaccount configured; use WithWatchAddress or a signing key")
+	ErrInvalidOrder  = errors.New("ethereal: invalid order")
+	ErrOrderNotFound = errors.New("ethereal: order not found")
+	ErrSchemaDrift   = errors.New("ethereal: EIP-712 schema drift")
+)
+
+// APIError is returned by Client.Do (and every method built on it) when the
+// venue answers with a non-2xx status.
+type APIError struct {
+	StatusCode int           // HTTP status code
+	Code       string        // venue error code, if any
+	Message    string        // venue error message, if any
+	Method     string        // request method
+	Path       string        // request path, relative to the base URL
+	RetryAfter time.Duration // parsed Retry-After header, zero if absent
+	Body       []byte        // raw response body
+
+	sentinel error
+}
+
+func (e *APIError) Error() string {
+	detail := e.Message
+	if detail == "" {
+		detail = strings.TrimSpace(string(e.Body))
+	}
+	if e.Code != "" {
+		detail = e.Code + ": " + detail
+	}
+	return fmt.Sprintf("ethereal error %d (%s %s): %s", e.StatusCode, e.Method, e.Path, detail)
+}
+
+// Is reports whether the error maps to one of the package sentinel errors.
+func (e *APIError) Is(target error) bool {
+	return e.sentinel != nil && e.sentinel == target
+}
+
+// venueError is the error envelope returned by the venue. message is either a
+// string or a list of validation messages.
+type venueError struct {
+	StatusCode int             `json:"statusCode"`
+	Code       string          `json:"code"`
+	Error      string          `json:"error"`
+	Message    json.RawMessage `json:"message"`
+}
+
+func newAPIError(resp *http.Response, method, path string, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Path:       path,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		Body:       body,
+	}
+
+	var payload venueError
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Code = payload.Code
+		if apiErr.Code == "" {
+			apiErr.Code = payload.Error
+		}
+		apiErr.Message = decodeVenueMessage(payload.Message)
+	}
+
+	apiErr.sentinel = classifyAPIError(apiErr)
+	return apiErr
+}
+
+func decodeVenueMessage(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return strings.Join(list, "; ")
+	}
+	return string(raw)
+}
+
+func classifyAPIError(e *APIError) error {
+	text := strings.ToLower(strings.ReplaceAll(e.Code+" "+e.Message, "_", " "))
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests || strings.Contains(text, "rate limit"):
+		return ErrRateLimited
+	case e.StatusCode == http.StatusServiceUnavailable || strings.Contains(text, "maintenance"):
+		return ErrMaintenance
+	case strings.Contains(text, "insufficient margin"):
+		return ErrInsufficientMargin
+	case strings.Contains(text, "invalid signature"):
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// parseRetryAfter accepts both delta-seconds and HTTP-date forms.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package etherealRest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_Do_apiErrorFields(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"statusCode":429,"message":"Too many requests","error":"Too Many Requests"}`))
+	}))
+	defer ts.Close()
+
+	cl := &Client{BaseURL: ts.URL, Http: ts.Client()}
+	_, err := cl.Do(context.Background(), http.MethodGet, "/v1/product", nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != 429 || apiErr.Method != http.MethodGet || apiErr.Path != "/v1/product" {
+		t.Fatalf("unexpected fields: %+v", apiErr)
+	}
+	if apiErr.Message != "Too many requests" || apiErr.Code != "Too Many Requests" {
+		t.Fatalf("unexpected payload decode: %+v", apiErr)
+	}
+	if apiErr.RetryAfter != 3*time.Second {
+		t.Fatalf("retry after: %v", apiErr.RetryAfter)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatal("expected ErrRateLimited")
+	}
+}
+
+func TestAPIError_sentinels(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{400, `{"statusCode":400,"message":"Insufficient margin for order"}`, ErrInsufficientMargin},
+		{400, `{"code":"INSUFFICIENT_MARGIN","message":"rejected"}`, ErrInsufficientMargin},
+		{401, `{"statusCode":401,"message":"Invalid signature"}`, ErrInvalidSignature},
+		{400, `{"message":["nonce must be a string","Invalid signature"]}`, ErrInvalidSignature},
+		{503, `upstream unavailable`, ErrMaintenance},
+		{500, `{"message":"Exchange is under maintenance"}`, ErrMaintenance},
+	}
+	for _, tc := range cases {
+		resp := &http.Response{StatusCode: tc.status, Header: http.Header{}}
+		err := newAPIError(resp, http.MethodPost, "/v1/order", []byte(tc.body))
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("%d %s: expected %v, got %v", tc.status, tc.body, tc.want, err)
+		}
+	}
+
+	resp := &http.Response{StatusCode: 400, Header: http.Header{}}
+	err := newAPIError(resp, http.MethodPost, "/v1/order", []byte(`{"message":"bad quantity"}`))
+	for _, sentinel := range []error{ErrRateLimited, ErrInsufficientMargin, ErrMaintenance, ErrInvalidSignature} {
+		if errors.Is(err, sentinel) {
+			t.Fatalf("unexpected match with %v", sentinel)
+		}
+	}
+}
@@ -18,7 +18,7 @@ func main() {
 		log.Fatal("ETHEREAL_PK is required (hex private key, with or without 0x prefix)")
 	}
 
-	client, err := rest.NewClient(ctx, pk, rest.Testnet)
+	client, err := rest.NewClient(ctx, rest.WithPrivateKey(pk), rest.WithEnvironment(rest.Testnet))
 	if err != nil {
 		log.Fatalf("failed to init ethereal client: %v", err)
 	}
@@ -18,7 +18,7 @@ func main() {
 		log.Fatal("ETHEREAL_PK is required (hex private key, with or without 0x prefix)")
 	}
 
-	client, err := rest.NewClient(ctx, pk, rest.Testnet)
+	client, err := rest.NewClient(ctx, rest.WithPrivateKey(pk), rest.WithEnvironment(rest.Testnet))
 	if err != nil {
 		log.Fatalf("failed to init ethereal client: %v", err)
 	}
@@ -31,7 +31,7 @@ func main() {
 	p := products["ETHUSD"]
 
 	// Initial resting limit (far from market on purpose for a safe demo).
-	placed, err := client.CreateOrder(ctx, p.NewOrder(rest.ORDER_LIMIT, 0.01, 1000.0, false, rest.BUY, rest.TIF_GTD))
+	placed, err := client.CreateOrder(ctx, p.NewOrder(rest.ORDER_LIMIT, rest.MustDecimal("0.01"), rest.MustDecimal("1000"), false, rest.BUY, rest.TIF_GTD))
 	if err != nil {
 		log.Fatalf("failed to place limit order: %v", err)
 	}
@@ -46,7 +46,7 @@ func main() {
 	}
 
 	// Replace: new order with updated price (two server operations; not atomic).
-	replaced, err := client.CreateOrder(ctx, p.NewOrder(rest.ORDER_LIMIT, 0.01, 1001.0, false, rest.BUY, rest.TIF_GTD))
+	replaced, err := client.CreateOrder(ctx, p.NewOrder(rest.ORDER_LIMIT, rest.MustDecimal("0.01"), rest.MustDecimal("1001"), false, rest.BUY, rest.TIF_GTD))
 	if err != nil {
 		log.Fatalf("failed to place replacement order: %v", err)
 	}
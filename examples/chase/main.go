@@ -1,16 +1,17 @@
 // Chase / follow-the-market (composition pattern).
 //
 // There is no "chase" or pegged-order API in ethereal-rest. This example shows
-// cancel-then-replace driven by a reference price that you must obtain yourself
-// (exchange feed, indexer, etc.). The stub below uses a fake moving reference.
+// cancel-then-replace driven by the venue's best bid from GetMarketPrices.
 //
-// Races: if the market moves between cancel and create, you can end up with no
-// working order or an unexpected fill. Use ClientOrderId on Order when the venue
-// supports idempotent client keys.
+// Every order carries a client order id from a ClientOrderIDGenerator, so the
+// replace is addressed by id and only sent once the cancel is acknowledged. A
+// fill of the old order before the cancel lands is still possible; it is
+// reported in the cancel's Filled.
 package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"time"
@@ -18,10 +19,19 @@ import (
 	rest "github.com/roundinternetmoney/ethereal-rest"
 )
 
-// referencePriceStub stands in for an external oracle or ticker. Replace with
-// your own data source; nothing in client.go provides live quotes for this loop.
-func referencePriceStub(iter int) float64 {
-	return 1000.0 + float64(iter)*0.5
+// referencePrice returns the current best bid of a product.
+func referencePrice(ctx context.Context, client *rest.Client, p rest.Product) (rest.Decimal, error) {
+	prices, err := client.GetMarketPrices(ctx, p.ID)
+	if err != nil {
+		return rest.Decimal{}, err
+	}
+	if len(prices) == 0 {
+		return rest.Decimal{}, fmt.Errorf("no market price for %s", p.Ticker)
+	}
+	if prices[0].BestBidPrice.IsZero() {
+		return rest.Decimal{}, fmt.Errorf("no best bid for %s", p.Ticker)
+	}
+	return prices[0].BestBidPrice, nil
 }
 
 func main() {
@@ -33,7 +43,12 @@ func main() {
 		log.Fatal("ETHEREAL_PK is required (hex private key, with or without 0x prefix)")
 	}
 
-	client, err := rest.NewClient(ctx, pk, rest.Testnet)
+	cloids, err := rest.NewClientOrderIDGenerator("chase")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := rest.NewClient(ctx, rest.WithPrivateKey(pk), rest.WithEnvironment(rest.Testnet), rest.WithClientOrderIDs(cloids))
 	if err != nil {
 		log.Fatalf("failed to init ethereal client: %v", err)
 	}
@@ -46,33 +61,35 @@ func main() {
 	p := products["ETHUSD"]
 
 	iterations := 2
-	qty := 0.01
+	qty := rest.MustDecimal("0.01")
+	offset := rest.MustDecimal("50")
 
-	var active *rest.OrderCreated
+	var active string // client order id of the working order
 	for i := 0; i < iterations; i++ {
-		ref := referencePriceStub(i)
+		ref, err := referencePrice(ctx, client, p)
+		if err != nil {
+			log.Fatalf("iteration %d: reference price: %v", i, err)
+		}
 		// Place or replace at reference (offset for a passive resting quote in demo).
-		targetPx := ref - 50.0
+		targetPx := ref.Sub(offset)
 
-		if active != nil {
-			cancelReq := rest.NewCancelOrderFromCreated(active)
-			if _, err := client.CancelOrder(ctx, cancelReq); err != nil {
-				log.Fatalf("iteration %d: cancel: %v", i, err)
-			}
-			active = nil
+		order := p.NewOrder(rest.ORDER_LIMIT, qty, targetPx, false, rest.BUY, rest.TIF_GTD)
+		var placed rest.OrderCreated
+		if active == "" {
+			placed, err = client.CreateOrder(ctx, order)
+		} else {
+			placed, _, err = client.ReplaceByClientID(ctx, active, order)
 		}
-
-		placed, err := client.CreateOrder(ctx, p.NewOrder(rest.ORDER_LIMIT, qty, targetPx, false, rest.BUY, rest.TIF_GTD))
 		if err != nil {
-			log.Fatalf("iteration %d: create: %v", i, err)
+			log.Fatalf("iteration %d: place: %v", i, err)
 		}
-		active = &placed
-		log.Printf("iteration %d: ref=%.4f limit=%.4f order=%+v", i, ref, targetPx, placed)
+		active = order.ClientOrderID
+		log.Printf("iteration %d: ref=%s limit=%s order=%+v", i, ref, targetPx, placed)
 		time.Sleep(500 * time.Millisecond)
 	}
 
-	if active != nil {
-		if _, err := client.CancelOrder(ctx, rest.NewCancelOrderFromCreated(active)); err != nil {
+	if active != "" {
+		if _, err := client.CancelByClientID(ctx, active); err != nil {
 			log.Fatalf("final cancel: %v", err)
 		}
 	}
@@ -18,7 +18,7 @@ func main() {
 		log.Fatal("ETHEREAL_PK is required (hex private key, with or without 0x prefix)")
 	}
 
-	client, err := rest.NewClient(ctx, pk, rest.Testnet)
+	client, err := rest.NewClient(ctx, rest.WithPrivateKey(pk), rest.WithEnvironment(rest.Testnet))
 	if err != nil {
 		log.Fatalf("failed to init ethereal client: %v", err)
 	}
@@ -32,8 +32,8 @@ func main() {
 
 	orders := make([]*rest.Order, 3)
 	for i := range orders {
-		px := 1000.1 + float64(i)
-		orders[i] = eth_perp.NewOrder(rest.ORDER_LIMIT, 0.123, px, false, rest.BUY, rest.TIF_GTD)
+		px := rest.MustDecimal("1000.1").Add(rest.DecimalFromInt(int64(i)))
+		orders[i] = eth_perp.NewOrder(rest.ORDER_LIMIT, rest.MustDecimal("0.123"), px, false, rest.BUY, rest.TIF_GTD)
 	}
 	placed, err := client.CreateOrders(ctx, orders)
 	if err != nil {
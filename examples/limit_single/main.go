@@ -18,7 +18,7 @@ func main() {
 		log.Fatal("ETHEREAL_PK is required (hex private key, with or without 0x prefix)")
 	}
 
-	client, err := rest.NewClient(ctx, pk, rest.Testnet)
+	client, err := rest.NewClient(ctx, rest.WithPrivateKey(pk), rest.WithEnvironment(rest.Testnet))
 	if err != nil {
 		log.Fatalf("failed to init ethereal client: %v", err)
 	}
@@ -30,7 +30,7 @@ func main() {
 	// place an order for ethusd
 	p := products["ETHUSD"]
 
-	order := p.NewOrder(rest.ORDER_LIMIT, 0.123, 1000.1, false, rest.BUY, rest.TIF_GTD)
+	order := p.NewOrder(rest.ORDER_LIMIT, rest.MustDecimal("0.123"), rest.MustDecimal("1000.1"), false, rest.BUY, rest.TIF_GTD)
 	placed, err := client.CreateOrder(ctx, order)
 	if err != nil {
 		log.Fatalf("failed to place limit order: %v", err)
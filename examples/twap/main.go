@@ -23,7 +23,7 @@ func main() {
 		log.Fatal("ETHEREAL_PK is required (hex private key, with or without 0x prefix)")
 	}
 
-	client, err := rest.NewClient(ctx, pk, rest.Testnet)
+	client, err := rest.NewClient(ctx, rest.WithPrivateKey(pk), rest.WithEnvironment(rest.Testnet))
 	if err != nil {
 		log.Fatalf("failed to init ethereal client: %v", err)
 	}
@@ -37,9 +37,10 @@ func main() {
 
 	slices := 3
 	interval := 2 * time.Second
-	qtyPerSlice := 0.01
+	qtyPerSlice := rest.MustDecimal("0.01")
 	// Far-from-market prices for demo only.
-	basePx := 1000.0
+	basePx := rest.MustDecimal("1000")
+	step := rest.MustDecimal("0.1")
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -54,7 +55,7 @@ func main() {
 			}
 		}
 
-		px := basePx + float64(i)*0.1
+		px := basePx.Add(step.Mul(rest.DecimalFromInt(int64(i))))
 		o, err := client.CreateOrder(ctx, p.NewOrder(rest.ORDER_LIMIT, qtyPerSlice, px, false, rest.BUY, rest.TIF_GTD))
 		if err != nil {
 			log.Fatalf("slice %d: create order: %v", i, err)
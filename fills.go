@@ -0,0 +1,98 @@
+package etherealRest
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// -------- BEGIN FILLS -------- //
+
+// Fill is one execution of one of the subaccount's orders.
+type Fill struct {
+	Id            string    `json:"id"`
+	OrderId       string    `json:"orderId"`
+	ClientOrderID string    `json:"clientOrderId"`
+	ProductId     string    `json:"productId"`
+	SubaccountId  string    `json:"subaccountId"`
+	Type          OrderType `json:"type"`
+	Side          OrderSide `json:"side"`
+	Price         Decimal   `json:"price"`
+	Quantity      Decimal   `json:"filled"`
+	FeeUsd        Decimal   `json:"feeUsd"`
+	IsMaker       bool      `json:"isMaker"`
+	ReduceOnly    bool      `json:"reduceOnly"`
+	CreatedAt     uint64    `json:"createdAt"` // milliseconds since epoch
+}
+
+// Trade is a public execution on a product.
+type Trade struct {
+	Id        string    `json:"id"`
+	ProductId string    `json:"productId"`
+	Price     Decimal   `json:"price"`
+	Quantity  Decimal   `json:"filled"`
+	TakerSide OrderSide `json:"takerSide"`
+	CreatedAt uint64    `json:"createdAt"` // milliseconds since epoch
+}
+
+// FillFilter narrows ListFills. Zero fields are not sent.
+type FillFilter struct {
+	ProductIDs    []string
+	Side          *OrderSide
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	PageOptions
+}
+
+func (f FillFilter) values(subaccountId string) url.Values {
+	q := url.Values{}
+	q.Set("subaccountId", subaccountId)
+	for _, id := range f.ProductIDs {
+		q.Add("productIds", id)
+	}
+	if f.Side != nil {
+		q.Set("side", strconv.FormatInt(int64(*f.Side), 10))
+	}
+	setTimeRange(q, f.CreatedAfter, f.CreatedBefore)
+	return q
+}
+
+// TradeFilter narrows ListTrades. Zero fields are not sent.
+type TradeFilter struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	PageOptions
+}
+
+func (f TradeFilter) values(productId string) url.Values {
+	q := url.Values{}
+	q.Set("productId", productId)
+	setTimeRange(q, f.CreatedAfter, f.CreatedBefore)
+	return q
+}
+
+func setTimeRange(q url.Values, after, before time.Time) {
+	if !after.IsZero() {
+		q.Set("createdAfter", strconv.FormatInt(after.UnixMilli(), 10))
+	}
+	if !before.IsZero() {
+		q.Set("createdBefore", strconv.FormatInt(before.UnixMilli(), 10))
+	}
+}
+
+// ListFills walks every fill of the subaccount matching filter, following the
+// venue's cursor pagination. Iteration stops at the first error.
+func (e *Client) ListFills(ctx context.Context, filter FillFilter) iter.Seq2[Fill, error] {
+	return subaccountSeq(ctx, e, func(sub *Subaccount) iter.Seq2[Fill, error] {
+		return Paginate[Fill](ctx, e, "/v1/order/fill", filter.values(sub.Id), filter.PageOptions)
+	})
+}
+
+// ListTrades walks every public trade on a product matching filter.
+func (e *Client) ListTrades(ctx context.Context, productId string, filter TradeFilter) iter.Seq2[Trade, error] {
+	return Paginate[Trade](ctx, e, "/v1/order/trade", filter.values(productId), filter.PageOptions)
+}
+
+// -------- END FILLS -------- //
@@ -0,0 +1,79 @@
+package etherealRest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestListFills_walksCursor(t *testing.T) {
+	var cursors []string
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/order/fill" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("createdBefore") != "1700000000000" || q.Get("limit") != "2" {
+			t.Errorf("query: %v", q)
+		}
+		cursors = append(cursors, q.Get("cursor"))
+		switch q.Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"hasNext":true,"nextCursor":"c2","data":[
+				{"id":"f1","orderId":"o1","clientOrderId":"cl1","price":"100","filled":"0.5","feeUsd":"0.01","isMaker":true},
+				{"id":"f2","orderId":"o1","price":"101","filled":"0.5"}]}`))
+		case "c2":
+			_, _ = w.Write([]byte(`{"hasNext":false,"data":[{"id":"f3","orderId":"o2","price":"99","filled":"1"}]}`))
+		}
+	})
+
+	var ids []string
+	for fill, err := range cl.ListFills(context.Background(), FillFilter{CreatedBefore: time.UnixMilli(1700000000000), PageOptions: PageOptions{Limit: 2}}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fill.Id == "f1" && (!fill.IsMaker || fill.Quantity.String() != "0.5" || fill.ClientOrderID != "cl1" || fill.FeeUsd.String() != "0.01") {
+			t.Fatalf("decoded: %+v", fill)
+		}
+		ids = append(ids, fill.Id)
+	}
+	if len(ids) != 3 || ids[2] != "f3" {
+		t.Fatalf("ids: %v", ids)
+	}
+	if len(cursors) != 2 || cursors[1] != "c2" {
+		t.Fatalf("cursors: %v", cursors)
+	}
+}
+
+func TestListTrades_stopsEarlyAndSurfacesErrors(t *testing.T) {
+	var calls int
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("productId") != "p1" {
+			http.Error(w, "bad product", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"hasNext":true,"nextCursor":"next","data":[{"id":"t1","price":"1","filled":"2","takerSide":1}]}`))
+	})
+
+	for trade, err := range cl.ListTrades(context.Background(), "p1", TradeFilter{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if trade.Id != "t1" || trade.TakerSide != SELL {
+			t.Fatalf("decoded: %+v", trade)
+		}
+		break
+	}
+	if calls != 1 {
+		t.Fatalf("breaking out must stop paging, calls=%d", calls)
+	}
+
+	for _, err := range cl.ListTrades(context.Background(), "nope", TradeFilter{}) {
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	}
+}
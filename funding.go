@@ -0,0 +1,105 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/url"
+	"time"
+)
+
+// -------- BEGIN FUNDING -------- //
+
+// FundingRate is one historical hourly funding rate of a product.
+type FundingRate struct {
+	ProductId     string  `json:"productId"`
+	FundingRate1h Decimal `json:"fundingRate1h"`
+	CreatedAt     uint64  `json:"createdAt"` // milliseconds since epoch
+}
+
+// ProjectedFunding is the funding rate the venue expects to charge at the next
+// funding interval of a product.
+type ProjectedFunding struct {
+	ProductId              string  `json:"productId"`
+	FundingRate1h          Decimal `json:"fundingRate1h"`
+	FundingRateProjected1h Decimal `json:"fundingRateProjected1h"`
+	NextFundingAt          uint64  `json:"nextFundingAt"` // milliseconds since epoch
+}
+
+// FundingPayment is funding paid or received by one of the subaccount's
+// positions. Positive FundingUsd is received, negative is paid.
+type FundingPayment struct {
+	Id           string    `json:"id"`
+	PositionId   string    `json:"positionId"`
+	ProductId    string    `json:"productId"`
+	SubaccountId string    `json:"subaccountId"`
+	Side         OrderSide `json:"side"`
+	Size         Decimal   `json:"size"`
+	FundingRate  Decimal   `json:"fundingRate"`
+	FundingUsd   Decimal   `json:"fundingUsd"`
+	CreatedAt    uint64    `json:"createdAt"` // milliseconds since epoch
+}
+
+// FundingFilter narrows funding history queries. Zero fields are not sent.
+type FundingFilter struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	PageOptions
+}
+
+// FundingPaymentFilter narrows ListFundingPayments. Zero fields are not sent.
+type FundingPaymentFilter struct {
+	ProductIDs    []string
+	PositionId    string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	PageOptions
+}
+
+func (f FundingPaymentFilter) values(subaccountId string) url.Values {
+	q := url.Values{}
+	q.Set("subaccountId", subaccountId)
+	for _, id := range f.ProductIDs {
+		q.Add("productIds", id)
+	}
+	if f.PositionId != "" {
+		q.Set("positionId", f.PositionId)
+	}
+	setTimeRange(q, f.CreatedAfter, f.CreatedBefore)
+	return q
+}
+
+// ListFundingRates walks the hourly funding rate history of a product.
+func (e *Client) ListFundingRates(ctx context.Context, productId string, filter FundingFilter) iter.Seq2[FundingRate, error] {
+	q := url.Values{}
+	q.Set("productId", productId)
+	setTimeRange(q, filter.CreatedAfter, filter.CreatedBefore)
+	return Paginate[FundingRate](ctx, e, "/v1/funding", q, filter.PageOptions)
+}
+
+// GetProjectedFunding returns the projected next funding rate for the given product ids.
+func (e *Client) GetProjectedFunding(ctx context.Context, productIds ...string) ([]*ProjectedFunding, error) {
+	q := url.Values{}
+	for _, id := range productIds {
+		q.Add("productIds", id)
+	}
+	data, err := e.Do(ctx, "GET", "/v1/funding/projected?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp Response[[]*ProjectedFunding]
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// ListFundingPayments walks the funding paid and received by the subaccount's positions.
+func (e *Client) ListFundingPayments(ctx context.Context, filter FundingPaymentFilter) iter.Seq2[FundingPayment, error] {
+	return subaccountSeq(ctx, e, func(sub *Subaccount) iter.Seq2[FundingPayment, error] {
+		return Paginate[FundingPayment](ctx, e, "/v1/position/funding", filter.values(sub.Id), filter.PageOptions)
+	})
+}
+
+// -------- END FUNDING -------- //
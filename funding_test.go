@@ -0,0 +1,49 @@
+package etherealRest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFunding_endpoints(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/v1/funding":
+			if q.Get("productId") != "p1" || q.Get("createdAfter") != "1700000000000" || q.Get("order") != "asc" {
+				t.Errorf("history query: %v", q)
+			}
+			_, _ = w.Write([]byte(`{"hasNext":false,"data":[{"fundingRate1h":"0.0000125","createdAt":1700000000000},{"fundingRate1h":"-0.000002","createdAt":1700003600000}]}`))
+		case "/v1/funding/projected":
+			_, _ = w.Write([]byte(`{"data":[{"productId":"p1","fundingRate1h":"0.0000125","fundingRateProjected1h":"0.00001","nextFundingAt":1700007200000}]}`))
+		case "/v1/position/funding":
+			if q.Get("subaccountId") == "" || q.Get("positionId") != "pos1" {
+				t.Errorf("payments query: %v", q)
+			}
+			_, _ = w.Write([]byte(`{"hasNext":false,"data":[{"id":"fp1","positionId":"pos1","fundingUsd":"-1.25","fundingRate":"0.0000125"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	ctx := context.Background()
+
+	rates, err := Collect(cl.ListFundingRates(ctx, "p1", FundingFilter{
+		CreatedAfter: time.UnixMilli(1700000000000),
+		PageOptions:  PageOptions{Order: SORT_ASC},
+	}))
+	if err != nil || len(rates) != 2 || rates[1].FundingRate1h.String() != "-0.000002" {
+		t.Fatalf("rates: %+v err: %v", rates, err)
+	}
+
+	projected, err := cl.GetProjectedFunding(ctx, "p1")
+	if err != nil || len(projected) != 1 || projected[0].FundingRateProjected1h.String() != "0.00001" || projected[0].NextFundingAt == 0 {
+		t.Fatalf("projected: %+v err: %v", projected, err)
+	}
+
+	payments, err := Collect(cl.ListFundingPayments(ctx, FundingPaymentFilter{PositionId: "pos1"}))
+	if err != nil || len(payments) != 1 || payments[0].FundingUsd.String() != "-1.25" {
+		t.Fatalf("payments: %+v err: %v", payments, err)
+	}
+}
@@ -0,0 +1,146 @@
+package etherealRest
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// -------- BEGIN ORDER GROUPS -------- //
+
+// OrderGroup is a set of orders linked by a shared GroupID. For OTO and OTOCO
+// groups the first order is the trigger and the rest are placed once it fills.
+type OrderGroup struct {
+	ID          string
+	Contingency ContingencyType
+	Orders      []*Order
+}
+
+// GroupCreated is the venue's response for every order of a group, in the
+// order they were submitted.
+type GroupCreated struct {
+	ID          string
+	Contingency ContingencyType
+	Orders      []*OrderCreated
+}
+
+// NewGroupID returns a random (version 4) UUID for Order.GroupID.
+func NewGroupID() string {
+	var b [16]byte
+	rand.Read(b[:]) // never fails, see crypto/rand
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// NewOrderGroup links orders under a fresh GroupID, setting GroupID and
+// GroupContingencyType on each of them.
+func NewOrderGroup(contingency ContingencyType, orders ...*Order) (*OrderGroup, error) {
+	if len(orders) < 2 {
+		return nil, fmt.Errorf("%w: a group needs at least two orders, got %d", ErrInvalidOrder, len(orders))
+	}
+	g := &OrderGroup{ID: NewGroupID(), Contingency: contingency, Orders: orders}
+	for _, o := range orders {
+		if o.GroupID != "" {
+			return nil, fmt.Errorf("%w: order is already in group %s", ErrInvalidOrder, o.GroupID)
+		}
+	}
+	for _, o := range orders {
+		c := contingency
+		o.GroupID = g.ID
+		o.GroupContingencyType = &c
+	}
+	return g, nil
+}
+
+// OCO links orders so that a fill of any one cancels the others.
+func OCO(orders ...*Order) (*OrderGroup, error) {
+	return NewOrderGroup(CONTINGENCY_OCO, orders...)
+}
+
+// OTO links children to trigger: they are only placed once trigger fills.
+func OTO(trigger *Order, children ...*Order) (*OrderGroup, error) {
+	return NewOrderGroup(CONTINGENCY_OTO, append([]*Order{trigger}, children...)...)
+}
+
+// OTOCO links children to trigger as in OTO, and the children to each other
+// as in OCO: once placed, a fill of one cancels the rest.
+func OTOCO(trigger *Order, children ...*Order) (*OrderGroup, error) {
+	if len(children) < 2 {
+		return nil, fmt.Errorf("%w: OTOCO needs at least two children, got %d", ErrInvalidOrder, len(children))
+	}
+	return NewOrderGroup(CONTINGENCY_OTOCO, append([]*Order{trigger}, children...)...)
+}
+
+// Bracket places entry with a take-profit and a stop-loss that are triggered
+// by its fill and cancel each other (OTOCO). Both exits are reduce-only
+// stop-market orders for the entry's quantity. Entry must be built from a
+// Product.
+func Bracket(entry *Order, takeProfit, stopLoss Decimal) (*OrderGroup, error) {
+	p := entry.product
+	if p == nil {
+		return nil, fmt.Errorf("%w: bracket entry must be built from a Product", ErrInvalidOrder)
+	}
+	if entry.Close || entry.ReduceOnly || entry.Quantity.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: bracket entry must open a position with a positive quantity", ErrInvalidOrder)
+	}
+
+	// a long entry exits above at the take profit and below at the stop, a short one the reverse
+	exit, above, below := SELL, takeProfit, stopLoss
+	if entry.Side == SELL {
+		exit, above, below = BUY, stopLoss, takeProfit
+	}
+	if !above.GreaterThan(below) {
+		return nil, fmt.Errorf("%w: take profit %s and stop loss %s are on the wrong sides of the entry", ErrInvalidOrder, takeProfit, stopLoss)
+	}
+	if entry.Type == ORDER_LIMIT && (!entry.Price.LessThan(above) || !entry.Price.GreaterThan(below)) {
+		return nil, fmt.Errorf("%w: entry price %s is not between %s and %s", ErrInvalidOrder, entry.Price, below, above)
+	}
+
+	tp, err := p.Market(exit).Qty(entry.Quantity).ReduceOnly().TakeProfit(takeProfit).Build()
+	if err != nil {
+		return nil, err
+	}
+	sl, err := p.Market(exit).Qty(entry.Quantity).ReduceOnly().StopLoss(stopLoss).Build()
+	if err != nil {
+		return nil, err
+	}
+	return OTOCO(entry, tp, sl)
+}
+
+// SendGroup validates every order of g and submits them in sequence, trigger
+// first. If an order is rejected the ones already placed are cancelled, so a
+// group is never left half open; the rejection is returned.
+func (e *Client) SendGroup(ctx context.Context, g *OrderGroup) (*GroupCreated, error) {
+	for _, o := range g.Orders {
+		if o.GroupID != g.ID {
+			return nil, fmt.Errorf("%w: order is not in group %s", ErrInvalidOrder, g.ID)
+		}
+		if err := o.normalize(e.RoundingPolicy()); err != nil {
+			return nil, err
+		}
+	}
+
+	created := &GroupCreated{ID: g.ID, Contingency: g.Contingency, Orders: make([]*OrderCreated, 0, len(g.Orders))}
+	for _, o := range g.Orders {
+		resp, err := o.Send(ctx, e, e.account)
+		if err != nil {
+			if len(created.Orders) > 0 {
+				if _, cerr := e.CancelGroup(context.WithoutCancel(ctx), created); cerr != nil {
+					err = errors.Join(err, fmt.Errorf("rolling back group %s: %w", g.ID, cerr))
+				}
+			}
+			return nil, err
+		}
+		created.Orders = append(created.Orders, &resp)
+	}
+	return created, nil
+}
+
+// CancelGroup cancels every order of a group in a single request.
+func (e *Client) CancelGroup(ctx context.Context, g *GroupCreated) ([]*OrderCancelled, error) {
+	return e.CancelOrdersFromCreated(ctx, g.Orders)
+}
+
+// -------- END ORDER GROUPS -------- //
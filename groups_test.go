@@ -0,0 +1,115 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestNewGroupID(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	a, b := NewGroupID(), NewGroupID()
+	if !uuid.MatchString(a) || a == b {
+		t.Fatalf("group ids %q %q", a, b)
+	}
+}
+
+func TestOCO(t *testing.T) {
+	p := testProduct()
+	one := MustDecimal("1")
+	a, _ := p.Limit(SELL).Qty(one).Price(MustDecimal("2100")).Build()
+	b, _ := p.Market(SELL).Qty(one).StopLoss(MustDecimal("1900")).Build()
+
+	g, err := OCO(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range g.Orders {
+		if o.GroupID != g.ID || *o.GroupContingencyType != CONTINGENCY_OCO {
+			t.Fatalf("order not linked: %+v", o)
+		}
+	}
+	if _, err := OCO(a, b); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("regrouping: %v", err)
+	}
+	if _, err := OCO(a); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("single order group: %v", err)
+	}
+}
+
+func TestBracket(t *testing.T) {
+	p := testProduct()
+	entry, _ := p.Limit(BUY).Qty(MustDecimal("2")).Price(MustDecimal("2000")).Build()
+	g, err := Bracket(entry, MustDecimal("2200"), MustDecimal("1900"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Contingency != CONTINGENCY_OTOCO || len(g.Orders) != 3 || g.Orders[0] != entry {
+		t.Fatalf("group: %+v", g)
+	}
+	for _, exit := range g.Orders[1:] {
+		if exit.Side != SELL || !exit.ReduceOnly || !exit.Quantity.Equal(entry.Quantity) || exit.GroupID != g.ID || *exit.GroupContingencyType != CONTINGENCY_OTOCO {
+			t.Fatalf("exit: %+v", exit)
+		}
+	}
+	if *g.Orders[1].StopType != STOP_TAKE_PROFIT || *g.Orders[2].StopType != STOP_LOSS {
+		t.Fatal("exits out of order")
+	}
+
+	short, _ := p.Limit(SELL).Qty(MustDecimal("1")).Price(MustDecimal("2000")).Build()
+	if _, err := Bracket(short, MustDecimal("2200"), MustDecimal("1900")); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("inverted short bracket: %v", err)
+	}
+	if _, err := Bracket(NewRawOrder(ORDER_MARKET, 1, PERPETUAL, MustDecimal("1"), Decimal{}, false, BUY, TIF_IOC), MustDecimal("2"), MustDecimal("1")); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("raw entry: %v", err)
+	}
+}
+
+func TestSendGroup_rollsBackOnRejection(t *testing.T) {
+	var mu sync.Mutex
+	var placed []Order
+	var cancelled []string
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/v1/order":
+			var msg struct{ Data Order }
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			if len(placed) == 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"statusCode":400,"message":"rejected"}`))
+				return
+			}
+			placed = append(placed, msg.Data)
+			_ = json.NewEncoder(w).Encode(OrderCreated{Id: msg.Data.ClientOrderID})
+		case "/v1/order/cancel":
+			var msg struct{ Data OrderCancel }
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			cancelled = append(cancelled, msg.Data.OrderIDs...)
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	})
+
+	p := testProduct()
+	entry, _ := p.Limit(BUY).Qty(MustDecimal("1")).Price(MustDecimal("2000")).ClientID("entry").Build()
+	g, err := Bracket(entry, MustDecimal("2200"), MustDecimal("1900"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Orders[1].ClientOrderID = "tp"
+
+	if _, err := cl.SendGroup(context.Background(), g); err == nil {
+		t.Fatal("expected rejection")
+	}
+	if len(placed) != 2 || placed[0].GroupID != g.ID || *placed[1].GroupContingencyType != CONTINGENCY_OTOCO {
+		t.Fatalf("placed: %+v", placed)
+	}
+	if len(cancelled) != 2 || cancelled[0] != "entry" || cancelled[1] != "tp" {
+		t.Fatalf("rollback cancelled %v", cancelled)
+	}
+}
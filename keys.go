@@ -0,0 +1,140 @@
+package etherealRest
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// -------- BEGIN KEY LOADING -------- //
+
+// DefaultDerivationPath is the BIP-44 path of the first Ethereum account, as
+// used by most wallets.
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+// ErrSignerClosed is returned when signing with a key that has been closed.
+var ErrSignerClosed = errors.New("ethereal: signer is closed")
+
+// LoadKeystore decrypts a Web3 Secret Storage (V3 JSON) keystore file and
+// returns a signer holding its key in memory until Close.
+func LoadKeystore(path, passphrase string) (*Signer, error) {
+	key, err := LoadKeystoreKey(path, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewDigestSigner(key), nil
+}
+
+// LoadKeystoreKey is LoadKeystore for use as a DigestSigner. The keystore is
+// decrypted once; neither the passphrase nor the ciphertext is kept.
+func LoadKeystoreKey(path, passphrase string) (*KeySigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("opening keystore %s: %w", path, err)
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+// NewMnemonicSigner derives a key from a BIP-39 mnemonic, an optional BIP-39
+// passphrase and a BIP-44 derivation path such as DefaultDerivationPath. The
+// mnemonic's checksum is verified so a mistyped word fails instead of
+// silently deriving another account.
+func NewMnemonicSigner(mnemonic, passphrase, path string) (*Signer, error) {
+	pk, err := deriveMnemonicKey(mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigner(pk), nil
+}
+
+func deriveMnemonicKey(mnemonic, passphrase, path string) (*ecdsa.PrivateKey, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	defer clear(seed)
+
+	derivation, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	node := mac.Sum(nil) // key || chain code
+	defer clear(node)
+	for _, index := range derivation {
+		if err := deriveChild(node, index); err != nil {
+			return nil, err
+		}
+	}
+	return crypto.ToECDSA(node[:32])
+}
+
+// deriveChild replaces the BIP-32 node (key || chain code) in place with its
+// private child at index.
+func deriveChild(node []byte, index uint32) error {
+	n := crypto.S256().Params().N
+	key, chain := node[:32], node[32:]
+
+	data := make([]byte, 0, 37)
+	if index >= 0x80000000 { // hardened
+		data = append(data, 0)
+		data = append(data, key...)
+	} else {
+		pk, err := crypto.ToECDSA(key)
+		if err != nil {
+			return err
+		}
+		data = append(data, crypto.CompressPubkey(&pk.PublicKey)...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	defer clear(data)
+
+	mac := hmac.New(sha512.New, chain)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	defer clear(sum)
+
+	tweak := new(big.Int).SetBytes(sum[:32])
+	child := new(big.Int).SetBytes(key)
+	child.Add(child, tweak).Mod(child, n)
+	if tweak.Cmp(n) >= 0 || child.Sign() == 0 {
+		return fmt.Errorf("derivation index %d yields an invalid key, use the next one", index)
+	}
+	child.FillBytes(key)
+	copy(chain, sum[32:])
+	tweak.SetInt64(0)
+	child.SetInt64(0)
+	return nil
+}
+
+// Close zeroes the signer's key material, if its DigestSigner holds any.
+// Signing afterwards fails with ErrSignerClosed.
+func (r *Signer) Close() error {
+	if r == nil {
+		return nil
+	}
+	if c, ok := r.key.(interface{ Close() error }); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// -------- END KEY LOADING -------- //
@@ -0,0 +1,127 @@
+package etherealRest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestNewMnemonicSigner(t *testing.T) {
+	cases := []struct {
+		path, passphrase, address string
+	}{
+		{DefaultDerivationPath, "", "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		{"m/44'/60'/0'/0/1", "", "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"},
+	}
+	for _, c := range cases {
+		s, err := NewMnemonicSigner(testMnemonic, c.passphrase, c.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Address != c.address {
+			t.Fatalf("%s: address %s, want %s", c.path, s.Address, c.address)
+		}
+	}
+
+	// extra whitespace is tolerated, a bad checksum is not
+	if _, err := NewMnemonicSigner("  "+strings.ReplaceAll(testMnemonic, " ", "\n  ")+" ", "", DefaultDerivationPath); err != nil {
+		t.Fatalf("whitespace: %v", err)
+	}
+	if _, err := NewMnemonicSigner(strings.Repeat("abandon ", 12), "", DefaultDerivationPath); err == nil {
+		t.Fatal("expected a checksum error")
+	}
+	if _, err := NewMnemonicSigner(testMnemonic, "", "m/x"); err == nil {
+		t.Fatal("expected a path error")
+	}
+}
+
+func TestLoadKeystore(t *testing.T) {
+	s, err := LoadKeystore(writeTestKeystore(t, "pass"), "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := crypto.PubkeyToAddress(mustECDSA(t, testKeyHex).PublicKey).Hex()
+	if s.Address != want {
+		t.Fatalf("address %s, want %s", s.Address, want)
+	}
+}
+
+func TestSignerClose(t *testing.T) {
+	pk := mustECDSA(t, testKeyHex)
+	s := NewSigner(pk)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if pk.D.Sign() != 0 || s.GetPk() != nil {
+		t.Fatal("key material survived Close")
+	}
+	digest := crypto.Keccak256([]byte("x"))
+	if _, err := s.GetDigestSigner().SignDigest(context.Background(), digest); !errors.Is(err, ErrSignerClosed) {
+		t.Fatalf("expected ErrSignerClosed, got %v", err)
+	}
+
+	ks, err := LoadKeystoreKey(writeTestKeystore(t, "pass"), "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks.Close()
+	if _, err := ks.SignDigest(context.Background(), digest); !errors.Is(err, ErrSignerClosed) {
+		t.Fatalf("expected ErrSignerClosed, got %v", err)
+	}
+}
+
+func TestWithMnemonic(t *testing.T) {
+	cl, err := NewClient(context.Background(), WithMnemonic(testMnemonic, "", ""), WithLazyInit())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cl.account.Address != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Fatalf("address %s", cl.account.Address)
+	}
+	if err := cl.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if cl.account.GetPk() != nil {
+		t.Fatal("Close kept the key")
+	}
+}
+
+// idleCloser records CloseIdleConnections calls made through an http.Client.
+type idleCloser struct {
+	http.RoundTripper
+	closed bool
+}
+
+func (c *idleCloser) CloseIdleConnections() { c.closed = true }
+
+func TestClientClose_leavesCallerResources(t *testing.T) {
+	pk := mustECDSA(t, testKeyHex)
+	transport := &idleCloser{RoundTripper: http.DefaultTransport}
+	cl, err := NewClient(context.Background(), WithSigner(NewSigner(pk)), WithHTTPClient(&http.Client{Transport: transport}), WithLazyInit())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if pk.D.Sign() == 0 || cl.account.GetPk() == nil {
+		t.Fatal("Close zeroed a caller's signer")
+	}
+	if transport.closed {
+		t.Fatal("Close released a caller's HTTP client")
+	}
+
+	cl, err = NewClient(context.Background(), WithDigestSigner(NewKeySigner(pk)), WithLazyInit())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.Close(); err != nil || pk.D.Sign() == 0 {
+		t.Fatalf("Close zeroed a caller's digest signer: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const linkedSignerSchema = "address sender, address signer, bytes32 subaccount, uint64 nonce, uint64 signedAt"
+
+func linkedTypedData(t *testing.T) *abi.TypedData {
+	t.Helper()
+	types := testTypedData(t)
+	fields, err := ParseTypeSchema(linkedSignerSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"LinkSigner", "RefreshLinkedSigner", "RevokeLinkedSigner"} {
+		types.Types[name] = fields
+	}
+	return types
+}
+
+// recoverSigner returns the address that signed msg as primaryType.
+func recoverSigner(t *testing.T, types *abi.TypedData, primaryType string, msg Signable, sig string) string {
+	t.Helper()
+	m, err := msg.ToMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain, err := types.HashStruct("EIP712Domain", types.Domain.Map())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := types.HashStruct(primaryType, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := hex.DecodeString(strings.TrimPrefix(sig, "0x"))
+	if err != nil || len(raw) != 65 {
+		t.Fatalf("signature %q", sig)
+	}
+	raw[64] -= 27
+	pub, err := crypto.SigToPub(MakeFullHash(domain, hash), raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.PubkeyToAddress(*pub).Hex()
+}
+
+func TestLinkedSigners(t *testing.T) {
+	owner := mustECDSA(t, testKeyHex)
+	ownerAddr := crypto.PubkeyToAddress(owner.PublicKey).Hex()
+	session, _ := crypto.GenerateKey()
+	sessionAddr := crypto.PubkeyToAddress(session.PublicKey).Hex()
+	types := linkedTypedData(t)
+	subName := "0x2222222222222222222222222222222222222222222222222222222222222222"
+
+	var mu sync.Mutex
+	var orderSender, orderSignedBy string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/v1/subaccount":
+			if sender := r.URL.Query().Get("sender"); sender != ownerAddr {
+				t.Errorf("subaccount looked up for %s, want the owner", sender)
+			}
+			_ = json.NewEncoder(w).Encode(Response[[]Subaccount]{Data: []Subaccount{{Id: "sub-1", Name: subName, Account: ownerAddr}}})
+		case "/v1/linked-signer/link", "/v1/linked-signer/revoke":
+			var req linkSignerRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			primaryType := "LinkSigner"
+			if strings.HasSuffix(r.URL.Path, "revoke") {
+				primaryType = "RevokeLinkedSigner"
+			}
+			if got := recoverSigner(t, types, primaryType, req.Data, req.Signature); got != ownerAddr {
+				t.Errorf("%s signed by %s, want owner", primaryType, got)
+			}
+			switch {
+			case primaryType == "LinkSigner" && recoverSigner(t, types, primaryType, req.Data, req.SignerSignature) != sessionAddr:
+				t.Error("link not countersigned by the session key")
+			case primaryType != "LinkSigner" && req.SignerSignature != "":
+				t.Error("only links carry a signer signature")
+			}
+			if req.Data.Sender != ownerAddr || req.Data.Signer != sessionAddr || req.Data.SubaccountId != "sub-1" {
+				t.Errorf("message %+v", req.Data)
+			}
+			_ = json.NewEncoder(w).Encode(LinkedSigner{Id: "ls-1", Signer: req.Data.Signer, Status: LINKED_SIGNER_PENDING})
+		case "/v1/linked-signer":
+			if r.URL.Query().Get("subaccountId") != "sub-1" {
+				t.Errorf("listed for %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":"ls-1","signer":"` + sessionAddr + `","status":"ACTIVE"}],"hasNext":false}`))
+		case "/v1/order":
+			var req SignedMessage[*Order]
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			orderSender = req.Data.Sender
+			orderSignedBy = recoverSigner(t, types, "TradeOrder", req.Data, req.Signature)
+			_ = json.NewEncoder(w).Encode(OrderCreated{Id: "o1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	ctx := context.Background()
+
+	ownerClient, err := NewClient(ctx, WithPrivateKey(testKeyHex), WithBaseURL(ts.URL), WithTypedData(types))
+	if err != nil {
+		t.Fatal(err)
+	}
+	linked, err := ownerClient.LinkSigner(ctx, NewKeySigner(session))
+	if err != nil || linked.Status != LINKED_SIGNER_PENDING {
+		t.Fatalf("link: %+v %v", linked, err)
+	}
+	all, err := Collect(ownerClient.ListLinkedSigners(ctx, PageOptions{}))
+	if err != nil || len(all) != 1 || all[0].Signer != sessionAddr {
+		t.Fatalf("list: %+v %v", all, err)
+	}
+
+	sessionClient, err := NewClient(ctx, WithDigestSigner(NewKeySigner(session)), WithLinkedSigner(strings.ToLower(ownerAddr)), WithBaseURL(ts.URL), WithTypedData(types))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sessionClient.CreateOrder(ctx, testProduct().NewOrder(ORDER_LIMIT, MustDecimal("1"), MustDecimal("100"), false, BUY, TIF_GTD)); err != nil {
+		t.Fatal(err)
+	}
+	if orderSender != sessionAddr || orderSignedBy != sessionAddr {
+		t.Fatalf("order sent as %s, signed by %s; want the session key", orderSender, orderSignedBy)
+	}
+	if _, err := sessionClient.RevokeLinkedSigner(ctx, sessionAddr); err == nil {
+		t.Fatal("a linked signer must not manage links")
+	}
+
+	if _, err := ownerClient.RevokeLinkedSigner(ctx, strings.ToLower(sessionAddr)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ownerClient.RevokeLinkedSigner(ctx, "nope"); err == nil {
+		t.Fatal("expected an address error")
+	}
+	for _, session := range []DigestSigner{nil, (*KeySigner)(nil), (*RemoteSigner)(nil)} {
+		if _, err := ownerClient.LinkSigner(ctx, session); err == nil {
+			t.Fatalf("expected an error for a nil session key %T", session)
+		}
+	}
+}
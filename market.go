@@ -0,0 +1,108 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// -------- BEGIN MARKET DATA -------- //
+
+// MarketPrice is the top of book and reference price of a product. The venue
+// marks positions to OraclePrice.
+type MarketPrice struct {
+	ProductId    string  `json:"productId"`
+	BestBidPrice Decimal `json:"bestBidPrice"`
+	BestAskPrice Decimal `json:"bestAskPrice"`
+	OraclePrice  Decimal `json:"oraclePrice"`
+	Price24hAgo  Decimal `json:"price24hAgo"`
+}
+
+// PriceLevel is one aggregated level of an order book, encoded by the venue
+// as a [price, quantity] pair.
+type PriceLevel struct {
+	Price    Decimal
+	Quantity Decimal
+}
+
+func (l *PriceLevel) UnmarshalJSON(b []byte) error {
+	var pair []Decimal
+	if err := json.Unmarshal(b, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("price level: expected [price, quantity], got %d values", len(pair))
+	}
+	l.Price, l.Quantity = pair[0], pair[1]
+	return nil
+}
+
+func (l PriceLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]Decimal{l.Price, l.Quantity})
+}
+
+// OrderBook is a depth snapshot of a product. Bids are sorted best (highest)
+// first and asks best (lowest) first.
+type OrderBook struct {
+	ProductId         string       `json:"productId"`
+	Timestamp         uint64       `json:"timestamp"`         // milliseconds since epoch
+	PreviousTimestamp uint64       `json:"previousTimestamp"` // milliseconds since epoch
+	Bids              []PriceLevel `json:"bids"`
+	Asks              []PriceLevel `json:"asks"`
+}
+
+// BestBid returns the top bid level, or nil if the side is empty.
+func (b *OrderBook) BestBid() *PriceLevel {
+	if len(b.Bids) == 0 {
+		return nil
+	}
+	return &b.Bids[0]
+}
+
+// BestAsk returns the top ask level, or nil if the side is empty.
+func (b *OrderBook) BestAsk() *PriceLevel {
+	if len(b.Asks) == 0 {
+		return nil
+	}
+	return &b.Asks[0]
+}
+
+// GetMarketPrices returns best bid/ask and oracle prices for the given product ids.
+func (e *Client) GetMarketPrices(ctx context.Context, productIds ...string) ([]*MarketPrice, error) {
+	q := url.Values{}
+	for _, id := range productIds {
+		q.Add("productIds", id)
+	}
+	data, err := e.Do(ctx, "GET", "/v1/product/market-price?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp Response[[]*MarketPrice]
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// GetMarketLiquidity returns an order book depth snapshot for a product.
+func (e *Client) GetMarketLiquidity(ctx context.Context, productId string) (*OrderBook, error) {
+	q := url.Values{}
+	q.Set("productId", productId)
+	data, err := e.Do(ctx, "GET", "/v1/product/market-liquidity?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var book OrderBook
+	if err := json.Unmarshal(data, &book); err != nil {
+		return nil, err
+	}
+	if book.ProductId == "" {
+		book.ProductId = productId
+	}
+
+	return &book, nil
+}
+
+// -------- END MARKET DATA -------- //
@@ -0,0 +1,66 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetMarketPrices_batch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/product/market-price" {
+			http.NotFound(w, r)
+			return
+		}
+		if ids := r.URL.Query()["productIds"]; len(ids) != 2 {
+			t.Errorf("productIds: %v", ids)
+		}
+		_, _ = w.Write([]byte(`{"data":[
+			{"productId":"p1","bestBidPrice":"1999.5","bestAskPrice":"2000.5","oraclePrice":"2000.123456789","price24hAgo":"1900"},
+			{"productId":"p2","bestBidPrice":"0.1","bestAskPrice":"0.2","oraclePrice":"0.15","price24hAgo":"0.1"}]}`))
+	}))
+	defer ts.Close()
+
+	cl := &Client{BaseURL: ts.URL, Http: ts.Client()}
+	prices, err := cl.GetMarketPrices(context.Background(), "p1", "p2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 2 || prices[0].OraclePrice.String() != "2000.123456789" || prices[1].BestAskPrice.String() != "0.2" {
+		t.Fatalf("prices: %+v", prices)
+	}
+}
+
+func TestGetMarketLiquidity_levels(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("productId") != "p1" {
+			http.Error(w, "missing product", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"timestamp":1700000000000,"bids":[["1999.5","3"],["1999","10"]],"asks":[["2000.5","1.25"]]}`))
+	}))
+	defer ts.Close()
+
+	cl := &Client{BaseURL: ts.URL, Http: ts.Client()}
+	book, err := cl.GetMarketLiquidity(context.Background(), "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.ProductId != "p1" || len(book.Bids) != 2 || book.BestBid().Price.String() != "1999.5" || book.BestAsk().Quantity.String() != "1.25" {
+		t.Fatalf("book: %+v", book)
+	}
+
+	b, err := json.Marshal(book.Bids[1])
+	if err != nil || string(b) != `["1999","10"]` {
+		t.Fatalf("level round trip: %s %v", b, err)
+	}
+	var bad PriceLevel
+	if err := json.Unmarshal([]byte(`["1"]`), &bad); err == nil {
+		t.Fatal("expected error for malformed level")
+	}
+	if (&OrderBook{}).BestAsk() != nil {
+		t.Fatal("empty side must return nil")
+	}
+}
@@ -3,7 +3,6 @@ package etherealRest
 import (
 	"fmt"
 	"math/big"
-	"strconv"
 	"time"
 
 	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
@@ -40,6 +39,32 @@ const (
 	SELL
 )
 
+// StopType marks an order as conditional: it rests untriggered until the
+// stop price is crossed. The venue has no trailing stops.
+type StopType int64
+
+const (
+	STOP_TAKE_PROFIT StopType = iota // triggers when price moves in the position's favour
+	STOP_LOSS                        // triggers when price moves against the position
+)
+
+// StopPriceType selects the price a conditional order is triggered by.
+type StopPriceType int64
+
+const (
+	STOP_PRICE_MARK StopPriceType = iota // oracle mark price
+	STOP_PRICE_LAST                      // last traded price
+)
+
+// ContingencyType links the orders sharing a GroupID.
+type ContingencyType int64
+
+const (
+	CONTINGENCY_OTO   ContingencyType = iota // the first order's fill places the others
+	CONTINGENCY_OCO                          // a fill of one order cancels the others
+	CONTINGENCY_OTOCO                        // as OTO, and a fill of one placed order cancels the rest
+)
+
 // -------- BEGIN ENUMS -------- //
 
 // -------- BEGIN ORDER -------- //
@@ -50,39 +75,43 @@ type Product struct {
 	DisplayTicker          string          `json:"displayTicker"`
 	EngineType             OrderEngineType `json:"engineType"`
 	OnchainID              int64           `json:"onchainId"`
-	LotSize                string          `json:"lotSize"`
-	TickSize               string          `json:"tickSize"`
-	MakerFee               string          `json:"makerFee"`
-	TakerFee               string          `json:"takerFee"`
-	MaxQuantity            string          `json:"maxQuantity"`
-	MinQuantity            string          `json:"minQuantity"`
-	Volume24h              string          `json:"volume24h"`
-	FundingRate1h          string          `json:"fundingRate1h"`
-	MaxOpenInterestUsd     string          `json:"maxOpenInterestUsd"`
-	MaxPositionNotionalUsd string          `json:"maxPositionNotionalUsd"`
+	LotSize                Decimal         `json:"lotSize"`
+	TickSize               Decimal         `json:"tickSize"`
+	MakerFee               Decimal         `json:"makerFee"`
+	TakerFee               Decimal         `json:"takerFee"`
+	MaxQuantity            Decimal         `json:"maxQuantity"`
+	MinQuantity            Decimal         `json:"minQuantity"`
+	Volume24h              Decimal         `json:"volume24h"`
+	FundingRate1h          Decimal         `json:"fundingRate1h"`
+	MaxOpenInterestUsd     Decimal         `json:"maxOpenInterestUsd"`
+	MaxPositionNotionalUsd Decimal         `json:"maxPositionNotionalUsd"`
 }
 
 type Order struct {
-	Subaccount           string          `json:"subaccount"`
-	Sender               string          `json:"sender"`
-	Nonce                string          `json:"nonce"` // string of nanoseconds
-	Type                 OrderType       `json:"type"`  // LIMIT or MARKET
-	Quantity             string          `json:"quantity"`
-	Side                 OrderSide       `json:"side"` // 0 BUY, 1 SELL
-	OnchainID            int64           `json:"onchainId"`
-	EngineType           OrderEngineType `json:"engineType"`
-	ClientOrderID        string          `json:"clientOrderId,omitempty"`
-	ReduceOnly           bool            `json:"reduceOnly"`
-	Close                bool            `json:"close,omitempty"`
-	StopPrice            int64           `json:"stopPrice,omitempty"`
-	StopType             int64           `json:"stopType,omitempty"`
-	SignedAt             int64           `json:"signedAt"` // seconds since epoch
-	ExpiresAt            int64           `json:"expiresAt,omitempty"`
-	GroupID              string          `json:"groupId,omitempty"` // UUID
-	GroupContingencyType int             `json:"groupContingencyType,omitempty"`
-	Price                string          `json:"price"`
-	TimeInForce          TimeInForce     `json:"timeInForce"`
-	PostOnly             bool            `json:"postOnly"`
+	Subaccount           string           `json:"subaccount"`
+	Sender               string           `json:"sender"`
+	Nonce                string           `json:"nonce"` // string of nanoseconds
+	Type                 OrderType        `json:"type"`  // LIMIT or MARKET
+	Quantity             Decimal          `json:"quantity"`
+	Side                 OrderSide        `json:"side"` // 0 BUY, 1 SELL
+	OnchainID            int64            `json:"onchainId"`
+	EngineType           OrderEngineType  `json:"engineType"`
+	ClientOrderID        string           `json:"clientOrderId,omitempty"`
+	ReduceOnly           bool             `json:"reduceOnly"`
+	Close                bool             `json:"close,omitempty"`
+	StopPrice            Decimal          `json:"stopPrice,omitzero"`
+	StopType             *StopType        `json:"stopType,omitempty"`      // nil for plain orders
+	StopPriceType        *StopPriceType   `json:"stopPriceType,omitempty"` // nil uses the venue default
+	SignedAt             int64            `json:"signedAt"`                // seconds since epoch
+	ExpiresAt            int64            `json:"expiresAt,omitempty"`
+	GroupID              string           `json:"groupId,omitempty"`              // UUID
+	GroupContingencyType *ContingencyType `json:"groupContingencyType,omitempty"` // nil outside a group
+	Price                Decimal          `json:"price"`
+	TimeInForce          TimeInForce      `json:"timeInForce"`
+	PostOnly             bool             `json:"postOnly"`
+
+	product *Product      // set by Product.NewOrder, used to normalize before signing
+	ttl     time.Duration // set by OrderBuilder.ExpiresIn, resolved against SignedAt
 }
 
 // needed for building
@@ -100,20 +129,20 @@ func NewRawOrder(
 	orderType OrderType,
 	onchainId int64,
 	marketType OrderEngineType,
-	qty float64,
-	px float64,
+	qty Decimal,
+	px Decimal,
 	reduce bool,
 	side OrderSide,
 	tif TimeInForce,
 ) *Order {
 	return &Order{
 		Type:        orderType,
-		Quantity:    fmt.Sprintf("%.9f", qty),
+		Quantity:    qty,
 		Side:        side,
 		OnchainID:   onchainId,
 		EngineType:  marketType,
 		ReduceOnly:  reduce,
-		Price:       fmt.Sprintf("%.9f", px),
+		Price:       px,
 		TimeInForce: tif,
 		PostOnly:    false,
 	}
@@ -121,34 +150,29 @@ func NewRawOrder(
 
 func (p *Product) NewOrder(
 	orderType OrderType,
-	qty float64,
-	px float64,
+	qty Decimal,
+	px Decimal,
 	reduce bool,
 	side OrderSide,
 	tif TimeInForce,
 ) *Order {
 	return &Order{
 		Type:        orderType,
-		Quantity:    fmt.Sprintf("%.9f", qty),
+		Quantity:    qty,
 		Side:        side,
 		OnchainID:   p.OnchainID,
 		EngineType:  p.EngineType,
 		ReduceOnly:  reduce,
-		Price:       fmt.Sprintf("%.9f", px),
+		Price:       px,
 		TimeInForce: tif,
 		PostOnly:    false,
+		product:     p,
 	}
 }
 
 func (o *Order) ToMessage() (abi.TypedDataMessage, error) {
-	qtyBig, err := Scale1e9(o.Quantity)
-	if err != nil {
-		return abi.TypedDataMessage{}, err
-	}
-	priceBig, err := Scale1e9(o.Price)
-	if err != nil {
-		return abi.TypedDataMessage{}, err
-	}
+	qtyBig := o.Quantity.Scaled()
+	priceBig := o.Price.Scaled()
 
 	// even though we expect these values to be uint8 according to their signatures,
 	// setting them as native uint8 raises a compiler error. strings or big ints are accepted.
@@ -171,14 +195,24 @@ func (o *Order) ToMessage() (abi.TypedDataMessage, error) {
 	}, nil
 }
 
-func (o *Order) Build(cl SubaccountHolder) {
+func (o *Order) Build(cl SubaccountHolder) error {
 	sub := cl.GetSubaccount()
-	o.Sender = sub.Account
+	o.Sender = senderOf(cl, sub)
 	o.Subaccount = sub.Name
-	nonce := getOrderNonce()
+	o.assignClientOrderID(cl)
 
-	o.Nonce = nonce
-	o.SignedAt, _ = strconv.ParseInt(nonce[:len(nonce)-9], 10, 64)
+	var err error
+	if o.Nonce, o.SignedAt, err = nextNonce(cl); err != nil {
+		return err
+	}
+	switch {
+	case o.ttl > 0:
+		o.ExpiresAt = o.SignedAt + int64((o.ttl+time.Second-1)/time.Second)
+	case o.ExpiresAt != 0 && o.ExpiresAt <= o.SignedAt:
+		// SignedAt follows the venue's clock once it has been synced
+		return fmt.Errorf("%w: expiry %s is in the past", ErrInvalidOrder, time.Unix(o.ExpiresAt, 0).UTC())
+	}
+	return nil
 }
 
 // -------- END ORDER -------- //
@@ -196,7 +230,7 @@ type OrderCancel struct {
 type OrderCancelled struct {
 	Id     string  `json:"id"`
 	Cloid  string  `json:"clientOrderId"`
-	Filled float64 `json:"filled"`
+	Filled Decimal `json:"filled"`
 	Result string  `json:"result"`
 }
 
@@ -229,11 +263,14 @@ func (o *OrderCancel) ToMessage() (abi.TypedDataMessage, error) {
 	}, nil
 }
 
-func (o *OrderCancel) Build(cl SubaccountHolder) {
+func (o *OrderCancel) Build(cl SubaccountHolder) error {
 	sub := cl.GetSubaccount()
-	o.Sender = sub.Account
+	o.Sender = senderOf(cl, sub)
 	o.Subaccount = sub.Name
-	o.Nonce = getOrderNonce()
+
+	var err error
+	o.Nonce, _, err = nextNonce(cl)
+	return err
 }
 
 // -------- END CANCEL -------- //
@@ -242,40 +279,33 @@ func (o *OrderCancel) Build(cl SubaccountHolder) {
 
 type Position struct {
 	Id                    string    `json:"id"`
-	Cost                  string    `json:"cost"`
-	Size                  string    `json:"size"`
-	FundingUsd            string    `json:"fundingUsd"`
-	FundingAccruedUsd     string    `json:"fundingAccruedUsd"`
-	FeesAccruedUsd        string    `json:"feesAccruedUsd"`
-	RealizedPnl           string    `json:"realizedPnl"`
-	TotalIncreaseNotional string    `json:"totalIncreaseNotional"`
-	TotalIncreaseQuantity string    `json:"totalIncreaseQuantity"`
-	TotalDecreaseNotional string    `json:"totalDecreaseNotional"`
-	TotalDecreaseQuantity string    `json:"totalDecreaseQuantity"`
+	Cost                  Decimal   `json:"cost"`
+	Size                  Decimal   `json:"size"`
+	FundingUsd            Decimal   `json:"fundingUsd"`
+	FundingAccruedUsd     Decimal   `json:"fundingAccruedUsd"`
+	FeesAccruedUsd        Decimal   `json:"feesAccruedUsd"`
+	RealizedPnl           Decimal   `json:"realizedPnl"`
+	TotalIncreaseNotional Decimal   `json:"totalIncreaseNotional"`
+	TotalIncreaseQuantity Decimal   `json:"totalIncreaseQuantity"`
+	TotalDecreaseNotional Decimal   `json:"totalDecreaseNotional"`
+	TotalDecreaseQuantity Decimal   `json:"totalDecreaseQuantity"`
 	Side                  OrderSide `json:"side"`
 	ProductId             string    `json:"productId"`
 	UpdatedAt             uint64    `json:"updatedAt"`
 	CreatedAt             uint64    `json:"createdAt"`
 	IsLiquidated          bool      `json:"isLiquidated"`
-	LiquidationPrice      string    `json:"liquidationPrice"`
+	LiquidationPrice      Decimal   `json:"liquidationPrice"`
 }
 
 // -------- BALANCE -------- //
 
 type AccountBalance struct {
-	SubaccountId string `json:"subaccountId"`
-	TokenId      string `json:"tokenId"`
-	TokenAddress string `json:"tokenAddress"`
-	TokenName    string `json:"tokenName"`
-	Amount       string `json:"amount"`
-	Available    string `json:"available"`
-	TotalUsed    string `json:"totalUsed"`
-	UpdatedAt    uint64 `json:"updatedAt"`
-}
-
-// -------- HELPERS -------- //
-
-func getOrderNonce() string {
-	now := time.Now()
-	return strconv.FormatInt(now.UnixNano(), 10)
+	SubaccountId string  `json:"subaccountId"`
+	TokenId      string  `json:"tokenId"`
+	TokenAddress string  `json:"tokenAddress"`
+	TokenName    string  `json:"tokenName"`
+	Amount       Decimal `json:"amount"`
+	Available    Decimal `json:"available"`
+	TotalUsed    Decimal `json:"totalUsed"`
+	UpdatedAt    uint64  `json:"updatedAt"`
 }
@@ -0,0 +1,160 @@
+package etherealRest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// -------- BEGIN NONCES -------- //
+
+// NonceSource issues the nonces signed into orders and cancels, in
+// nanoseconds since the epoch. Implementations must be safe for concurrent use
+// and never return the same value twice.
+type NonceSource interface {
+	Next() (int64, error)
+}
+
+// NonceStore persists the highest nonce a MonotonicNonce may have issued, so a
+// restarted process never reuses one.
+type NonceStore interface {
+	Load() (int64, error) // zero if nothing was saved yet
+	Save(int64) error
+}
+
+// nonceReserve is how far ahead of the issued nonces the persisted high-water
+// mark is kept, so the store is written about once per second of traffic.
+const nonceReserve = int64(time.Second)
+
+// MonotonicNonce is the default NonceSource: the wall clock plus an optional
+// server offset, bumped by one nanosecond whenever the clock has not advanced
+// or has stepped backwards. Issuing is lock-free; only advancing the
+// persisted high-water mark takes a lock.
+type MonotonicNonce struct {
+	last   atomic.Int64
+	offset atomic.Int64 // nanoseconds added to the local clock
+
+	store    NonceStore
+	mu       sync.Mutex
+	reserved atomic.Int64 // persisted high-water mark
+	now      func() time.Time
+}
+
+// NewMonotonicNonce returns a nonce source resuming above the high-water mark
+// in store. store may be nil to keep nonces in memory only.
+func NewMonotonicNonce(store NonceStore) (*MonotonicNonce, error) {
+	n := &MonotonicNonce{store: store, now: time.Now}
+	if store != nil {
+		mark, err := store.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading nonce high-water mark: %w", err)
+		}
+		n.last.Store(mark)
+		n.reserved.Store(mark)
+	}
+	return n, nil
+}
+
+// SetOffset shifts issued nonces by d, e.g. the server clock's lead over the
+// local one. Nonces stay strictly increasing when d shrinks.
+func (n *MonotonicNonce) SetOffset(d time.Duration) {
+	n.offset.Store(int64(d))
+}
+
+func (n *MonotonicNonce) Offset() time.Duration {
+	return time.Duration(n.offset.Load())
+}
+
+func (n *MonotonicNonce) Next() (int64, error) {
+	for {
+		last := n.last.Load()
+		next := max(n.now().UnixNano()+n.offset.Load(), last+1)
+		if n.last.CompareAndSwap(last, next) {
+			return next, n.reserve(next)
+		}
+	}
+}
+
+// reserve makes sure the persisted high-water mark is at or above nonce
+// before it is handed out.
+func (n *MonotonicNonce) reserve(nonce int64) error {
+	if n.store == nil || nonce <= n.reserved.Load() {
+		return nil
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if nonce <= n.reserved.Load() {
+		return nil
+	}
+	mark := nonce + nonceReserve
+	if err := n.store.Save(mark); err != nil {
+		return fmt.Errorf("saving nonce high-water mark: %w", err)
+	}
+	n.reserved.Store(mark)
+	return nil
+}
+
+// FileNonceStore keeps the high-water mark in a file, replaced atomically on
+// every save.
+type FileNonceStore struct {
+	Path string
+}
+
+func (f FileNonceStore) Load() (int64, error) {
+	b, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+}
+
+func (f FileNonceStore) Save(mark int64) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(strconv.FormatInt(mark, 10)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// nonceHolder is implemented by clients whose signer carries a NonceSource.
+type nonceHolder interface {
+	Nonces() NonceSource
+}
+
+// nextNonce draws a nonce for a message built by cl, returning it as the
+// venue's decimal string along with its signedAt in seconds. Holders without
+// a NonceSource get the local wall clock.
+func nextNonce(cl SubaccountHolder) (string, int64, error) {
+	n := time.Now().UnixNano()
+	if h, ok := cl.(nonceHolder); ok && h.Nonces() != nil {
+		var err error
+		if n, err = h.Nonces().Next(); err != nil {
+			return "", 0, err
+		}
+	}
+	return strconv.FormatInt(n, 10), n / int64(time.Second), nil
+}
+
+// -------- END NONCES -------- //
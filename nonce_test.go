@@ -0,0 +1,129 @@
+package etherealRest
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMonotonicNonce_concurrent(t *testing.T) {
+	n, _ := NewMonotonicNonce(nil)
+	const workers, each = 8, 2000
+	out := make([][]int64, workers)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			for range each {
+				v, err := n.Next()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				out[w] = append(out[w], v)
+			}
+		})
+	}
+	wg.Wait()
+
+	seen := make(map[int64]bool, workers*each)
+	for _, vs := range out {
+		for i, v := range vs {
+			if seen[v] {
+				t.Fatalf("nonce %d issued twice", v)
+			}
+			seen[v] = true
+			if i > 0 && v <= vs[i-1] {
+				t.Fatalf("nonce went backwards within a goroutine: %d after %d", v, vs[i-1])
+			}
+		}
+	}
+}
+
+func TestMonotonicNonce_clockStepsBack(t *testing.T) {
+	n, _ := NewMonotonicNonce(nil)
+	clock := time.Unix(1_700_000_000, 0)
+	n.now = func() time.Time { return clock }
+
+	first, _ := n.Next()
+	clock = clock.Add(-time.Minute)
+	second, _ := n.Next()
+	if second != first+1 {
+		t.Fatalf("after a backwards step got %d, want %d", second, first+1)
+	}
+
+	n.SetOffset(2 * time.Minute)
+	third, _ := n.Next()
+	if want := clock.Add(2 * time.Minute).UnixNano(); third != want {
+		t.Fatalf("with offset got %d, want %d", third, want)
+	}
+	n.SetOffset(0)
+	if fourth, _ := n.Next(); fourth != third+1 {
+		t.Fatalf("shrinking the offset went backwards: %d after %d", fourth, third)
+	}
+}
+
+func TestMonotonicNonce_persisted(t *testing.T) {
+	store := FileNonceStore{Path: filepath.Join(t.TempDir(), "nonce")}
+	clock := time.Unix(1_700_000_000, 0)
+
+	n, err := NewMonotonicNonce(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.now = func() time.Time { return clock }
+	issued, err := n.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a restart with the clock set back must resume above everything issued
+	restarted, err := NewMonotonicNonce(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarted.now = func() time.Time { return clock.Add(-time.Hour) }
+	if next, _ := restarted.Next(); next <= issued {
+		t.Fatalf("restart reused nonce space: %d <= %d", next, issued)
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Load() (int64, error) { return 0, nil }
+func (failingStore) Save(int64) error     { return errors.New("disk full") }
+
+func TestMonotonicNonce_storeErrors(t *testing.T) {
+	n, _ := NewMonotonicNonce(failingStore{})
+	if _, err := n.Next(); err == nil {
+		t.Fatal("expected the save error")
+	}
+
+	o := NewRawOrder(ORDER_LIMIT, 1, PERPETUAL, MustDecimal("1"), MustDecimal("1"), false, BUY, TIF_GTD)
+	s := &Signer{Subaccount: &Subaccount{}}
+	s.SetNonceSource(n)
+	if err := o.Build(nonceTestClient{s}); err == nil {
+		t.Fatal("Build must surface nonce errors")
+	}
+}
+
+func TestOrderBuild_signedAtFromNonce(t *testing.T) {
+	o := NewRawOrder(ORDER_LIMIT, 1, PERPETUAL, MustDecimal("1"), MustDecimal("1"), false, BUY, TIF_GTD)
+	if err := o.Build(nonceTestClient{&Signer{Subaccount: &Subaccount{}}}); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := strconv.ParseInt(o.Nonce, 10, 64); n/int64(time.Second) != o.SignedAt {
+		t.Fatalf("nonce %s does not match signedAt %d", o.Nonce, o.SignedAt)
+	}
+	if now := time.Now().Unix(); o.SignedAt < now-1 || o.SignedAt > now {
+		t.Fatalf("signedAt %d, now %d", o.SignedAt, now)
+	}
+}
+
+type nonceTestClient struct{ s *Signer }
+
+func (c nonceTestClient) GetSubaccount() *Subaccount { return c.s.Subaccount }
+func (c nonceTestClient) Nonces() NonceSource        { return c.s.Nonces() }
@@ -0,0 +1,173 @@
+package etherealRest
+
+import (
+	"log/slog"
+	"net/http"
+
+	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Option configures a Client built by NewClient.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	baseURL        string
+	http           *http.Client
+	privateKey     string
+	signer         *Signer
+	digestSigner   DigestSigner
+	loadSigner     func() (*Signer, error)
+	owner          string
+	watchAddress   string
+	readOnly       bool
+	subaccountName string
+	subaccountID   string
+	types          *abi.TypedData
+	logger         *slog.Logger
+	userAgent      string
+	lazy           bool
+	retryPolicy    *RetryPolicy
+	limiter        *RateLimiter
+	limiterSet     bool
+	rounding       RoundingPolicy
+	cloids         *ClientOrderIDGenerator
+	nonces         NonceSource
+	allowDrift     bool
+}
+
+// WithEnvironment targets one of the venue environments (default Testnet).
+func WithEnvironment(env Environment) Option {
+	return func(o *clientOptions) { o.baseURL = string(env) }
+}
+
+// WithBaseURL targets an arbitrary API base URL, e.g. a proxy or test server.
+func WithBaseURL(url string) Option {
+	return func(o *clientOptions) { o.baseURL = url }
+}
+
+// WithHTTPClient replaces the default pooled HTTP client and its 10s timeout.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *clientOptions) { o.http = c }
+}
+
+// WithPrivateKey signs with a hex encoded private key, with or without 0x prefix.
+func WithPrivateKey(pk string) Option {
+	return func(o *clientOptions) { o.privateKey = pk }
+}
+
+// WithSigner signs with an existing Signer; it takes precedence over WithPrivateKey.
+func WithSigner(s *Signer) Option {
+	return func(o *clientOptions) { o.signer = s }
+}
+
+// WithDigestSigner signs through key, e.g. a RemoteSigner, instead of a
+// private key held by the client.
+func WithDigestSigner(key DigestSigner) Option {
+	return func(o *clientOptions) { o.digestSigner = key }
+}
+
+// WithKeystore signs with the key of a V3 JSON keystore file, see LoadKeystore.
+func WithKeystore(path, passphrase string) Option {
+	return func(o *clientOptions) {
+		o.loadSigner = func() (*Signer, error) { return LoadKeystore(path, passphrase) }
+	}
+}
+
+// WithMnemonic signs with a key derived from a BIP-39 mnemonic, see
+// NewMnemonicSigner. An empty path uses DefaultDerivationPath.
+func WithMnemonic(mnemonic, passphrase, path string) Option {
+	if path == "" {
+		path = DefaultDerivationPath
+	}
+	return func(o *clientOptions) {
+		o.loadSigner = func() (*Signer, error) { return NewMnemonicSigner(mnemonic, passphrase, path) }
+	}
+}
+
+// WithWatchAddress makes the client read-only for the given wallet address:
+// account endpoints resolve its subaccount, signing methods return ErrReadOnly.
+// NewClient fails if a key is given as well.
+func WithWatchAddress(address string) Option {
+	return func(o *clientOptions) { o.watchAddress = address }
+}
+
+// WithSubaccountName selects the subaccount whose bytes32 name matches,
+// instead of the first one discovered.
+func WithSubaccountName(name string) Option {
+	return func(o *clientOptions) { o.subaccountName = name }
+}
+
+// WithSubaccountID selects the subaccount with the given id, instead of the
+// first one discovered.
+func WithSubaccountID(id string) Option {
+	return func(o *clientOptions) { o.subaccountID = id }
+}
+
+// WithTypedData uses the given EIP-712 types and domain instead of fetching
+// them from /v1/rpc/config.
+func WithTypedData(t *abi.TypedData) Option {
+	return func(o *clientOptions) { o.types = t }
+}
+
+// WithSchemaDriftAllowed signs messages whose EIP-712 schema differs from the
+// venue's (see CheckSchema) instead of refusing them. The drift is still
+// logged. Messages are hashed against the venue's schema, so fields the client
+// does not emit still fail to encode.
+func WithSchemaDriftAllowed() Option {
+	return func(o *clientOptions) { o.allowDrift = true }
+}
+
+// WithLogger routes client diagnostics (retries, lazy init) to l.
+func WithLogger(l *slog.Logger) Option {
+	return func(o *clientOptions) { o.logger = l }
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(o *clientOptions) { o.userAgent = ua }
+}
+
+// WithLazyInit defers the rpc config and subaccount lookups until the first
+// call that needs them, so NewClient performs no network calls.
+func WithLazyInit() Option {
+	return func(o *clientOptions) { o.lazy = true }
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *clientOptions) { o.retryPolicy = &p }
+}
+
+// WithRoundingPolicy controls how orders built from a Product are rounded to
+// tick and lot size and validated before signing.
+func WithRoundingPolicy(p RoundingPolicy) Option {
+	return func(o *clientOptions) { o.rounding = p }
+}
+
+// WithRateLimiter replaces the default limiter; nil disables client-side throttling.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.limiter = l
+		o.limiterSet = true
+	}
+}
+
+// WithClientOrderIDs assigns an id from g to every order sent without a
+// ClientOrderID.
+func WithClientOrderIDs(g *ClientOrderIDGenerator) Option {
+	return func(o *clientOptions) { o.cloids = g }
+}
+
+// WithNonceSource replaces the signer's nonces, e.g. a MonotonicNonce backed by
+// a FileNonceStore so restarts never reuse one.
+func WithNonceSource(n NonceSource) Option {
+	return func(o *clientOptions) { o.nonces = n }
+}
+
+// WithLinkedSigner makes the client trade the subaccount of owner with its
+// own key as a linked (session) signer; combine it with a key option such as
+// WithPrivateKey or WithDigestSigner. Orders and cancels are sent with the
+// session key's address as sender.
+func WithLinkedSigner(owner string) Option {
+	return func(o *clientOptions) { o.owner = owner }
+}
@@ -0,0 +1,125 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func testTypedData(t testing.TB) *abi.TypedData {
+	t.Helper()
+	var cfg struct {
+		Domain   abi.TypedDataDomain `json:"domain"`
+		SigTypes map[string]string   `json:"signatureTypes"`
+	}
+	if err := json.Unmarshal(testRPCConfigJSON, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	types := abi.Types{
+		"EIP712Domain": {
+			{Name: "name", Type: "string"},
+			{Name: "version", Type: "string"},
+			{Name: "chainId", Type: "uint256"},
+			{Name: "verifyingContract", Type: "address"},
+		},
+	}
+	for name, schema := range cfg.SigTypes {
+		fields, err := ParseTypeSchema(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		types[name] = fields
+	}
+	return &abi.TypedData{Types: types, Domain: cfg.Domain}
+}
+
+func TestNewClient_requiresKey(t *testing.T) {
+	if _, err := NewClient(context.Background(), WithLazyInit()); err == nil {
+		t.Fatal("expected error without a key")
+	}
+}
+
+func TestNewClient_lazyInitAndTypedData(t *testing.T) {
+	var configCalls, subaccountCalls, orderCalls atomic.Int32
+	var userAgent atomic.Value
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent.Store(r.UserAgent())
+		switch {
+		case r.URL.Path == "/v1/rpc/config":
+			configCalls.Add(1)
+			_, _ = w.Write(testRPCConfigJSON)
+		case r.URL.Path == "/v1/subaccount":
+			subaccountCalls.Add(1)
+			sender := r.URL.Query().Get("sender")
+			_ = json.NewEncoder(w).Encode(Response[[]Subaccount]{Data: []Subaccount{
+				{Id: "0x01", Name: "0x0000000000000000000000000000000000000000000000000000000000000001", Account: sender},
+				{Id: "0x02", Name: "0x0000000000000000000000000000000000000000000000000000000000000002", Account: sender},
+			}})
+		case r.URL.Path == "/v1/order":
+			orderCalls.Add(1)
+			_, _ = w.Write([]byte(`{"id":"oid1","result":"ok"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	cl, err := NewClient(context.Background(),
+		WithPrivateKey("0x0bb5d63b84421e1268dda020818ae30cf26e7f10e321fb820a8aa69216dea92a"),
+		WithBaseURL(ts.URL),
+		WithHTTPClient(ts.Client()),
+		WithTypedData(testTypedData(t)),
+		WithSubaccountID("0x02"),
+		WithUserAgent("bot/1"),
+		WithLazyInit(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subaccountCalls.Load() != 0 || configCalls.Load() != 0 {
+		t.Fatal("lazy client must not touch the network on construction")
+	}
+
+	order := NewRawOrder(ORDER_LIMIT, 1, PERPETUAL, DecimalFromInt(1), DecimalFromInt(100), false, BUY, TIF_GTD)
+	if _, err := cl.CreateOrder(context.Background(), order); err != nil {
+		t.Fatal(err)
+	}
+	if configCalls.Load() != 0 {
+		t.Fatal("WithTypedData must skip /v1/rpc/config")
+	}
+	if subaccountCalls.Load() != 1 || orderCalls.Load() != 1 {
+		t.Fatalf("subaccount calls %d order calls %d", subaccountCalls.Load(), orderCalls.Load())
+	}
+	if cl.GetSubaccount().Id != "0x02" || order.Subaccount != cl.GetSubaccount().Name {
+		t.Fatalf("wrong subaccount selected: %+v", cl.GetSubaccount())
+	}
+	if userAgent.Load() != "bot/1" {
+		t.Fatalf("user agent: %v", userAgent.Load())
+	}
+}
+
+func TestNewClient_unknownSubaccount(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/rpc/config":
+			_, _ = w.Write(testRPCConfigJSON)
+		default:
+			_, _ = w.Write(testSubaccountFixture)
+		}
+	}))
+	defer ts.Close()
+
+	_, err := NewClient(context.Background(),
+		WithPrivateKey("0bb5d63b84421e1268dda020818ae30cf26e7f10e321fb820a8aa69216dea92a"),
+		WithBaseURL(ts.URL),
+		WithSubaccountName("0xdead"),
+	)
+	if err == nil {
+		t.Fatal("expected error for unknown subaccount name")
+	}
+}
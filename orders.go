@@ -0,0 +1,113 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// -------- BEGIN ORDER QUERIES -------- //
+
+type OrderStatus string
+
+const (
+	STATUS_NEW            OrderStatus = "NEW"
+	STATUS_PENDING        OrderStatus = "PENDING"
+	STATUS_FILLED_PARTIAL OrderStatus = "FILLED_PARTIAL"
+	STATUS_FILLED         OrderStatus = "FILLED"
+	STATUS_REJECTED       OrderStatus = "REJECTED"
+	STATUS_CANCELED       OrderStatus = "CANCELED"
+	STATUS_EXPIRED        OrderStatus = "EXPIRED"
+)
+
+// Working reports whether an order in this status can still fill.
+func (s OrderStatus) Working() bool {
+	return s == STATUS_NEW || s == STATUS_PENDING || s == STATUS_FILLED_PARTIAL
+}
+
+// OrderInfo is an order as reported by the venue's order query endpoints.
+type OrderInfo struct {
+	Id                   string           `json:"id"`
+	ClientOrderID        string           `json:"clientOrderId"`
+	Type                 OrderType        `json:"type"`
+	Status               OrderStatus      `json:"status"`
+	Side                 OrderSide        `json:"side"`
+	ProductId            string           `json:"productId"`
+	SubaccountId         string           `json:"subaccountId"`
+	Sender               string           `json:"sender"`
+	Price                Decimal          `json:"price"`
+	Quantity             Decimal          `json:"quantity"`
+	AvailableQuantity    Decimal          `json:"availableQuantity"`
+	Filled               Decimal          `json:"filled"`
+	AveragePrice         Decimal          `json:"averagePrice"`
+	ReduceOnly           bool             `json:"reduceOnly"`
+	Close                bool             `json:"close"`
+	PostOnly             bool             `json:"postOnly"`
+	TimeInForce          TimeInForce      `json:"timeInForce"`
+	EngineType           OrderEngineType  `json:"engineType"`
+	StopPrice            Decimal          `json:"stopPrice"`
+	StopType             *StopType        `json:"stopType"`
+	StopPriceType        *StopPriceType   `json:"stopPriceType"`
+	GroupID              string           `json:"groupId"`
+	GroupContingencyType *ContingencyType `json:"groupContingencyType"`
+	ExpiresAt            uint64           `json:"expiresAt"` // seconds since epoch
+	CreatedAt            uint64           `json:"createdAt"` // milliseconds since epoch
+	UpdatedAt            uint64           `json:"updatedAt"` // milliseconds since epoch
+}
+
+// OrderFilter narrows ListOrders. Zero fields are not sent.
+type OrderFilter struct {
+	ProductIDs    []string
+	Side          *OrderSide
+	Statuses      []OrderStatus
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	ClientOrderID string
+	PageOptions
+}
+
+func (f OrderFilter) values(subaccountId string) url.Values {
+	q := url.Values{}
+	q.Set("subaccountId", subaccountId)
+	for _, id := range f.ProductIDs {
+		q.Add("productIds", id)
+	}
+	if f.Side != nil {
+		q.Set("side", strconv.FormatInt(int64(*f.Side), 10))
+	}
+	for _, s := range f.Statuses {
+		q.Add("statuses", string(s))
+	}
+	setTimeRange(q, f.CreatedAfter, f.CreatedBefore)
+	if f.ClientOrderID != "" {
+		q.Set("clientOrderId", f.ClientOrderID)
+	}
+	return q
+}
+
+// ListOrders walks every order of the subaccount matching filter, following
+// the venue's cursor pagination. Use Collect to gather them into a slice.
+func (e *Client) ListOrders(ctx context.Context, filter OrderFilter) iter.Seq2[OrderInfo, error] {
+	return subaccountSeq(ctx, e, func(sub *Subaccount) iter.Seq2[OrderInfo, error] {
+		return Paginate[OrderInfo](ctx, e, "/v1/order", filter.values(sub.Id), filter.PageOptions)
+	})
+}
+
+// GetOrder returns a single order by its venue id.
+func (e *Client) GetOrder(ctx context.Context, id string) (*OrderInfo, error) {
+	data, err := e.Do(ctx, "GET", "/v1/order/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	var order OrderInfo
+	if err := json.Unmarshal(data, &order); err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// -------- END ORDER QUERIES -------- //
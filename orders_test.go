@@ -0,0 +1,72 @@
+package etherealRest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestListOrders_filterQuery(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/order" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("subaccountId") != "0x1111111111111111111111111111111111111111111111111111111111111111" {
+			t.Errorf("subaccountId: %q", q.Get("subaccountId"))
+		}
+		if got := q["productIds"]; len(got) != 2 || got[1] != "p2" {
+			t.Errorf("productIds: %v", got)
+		}
+		if q.Get("side") != "1" || q.Get("statuses") != "NEW" || q.Get("clientOrderId") != "c1" {
+			t.Errorf("filters: %v", q)
+		}
+		if q.Get("createdAfter") != "1700000000000" || q.Get("limit") != "50" {
+			t.Errorf("time/limit: %v", q)
+		}
+		if q.Has("createdBefore") || q.Has("cursor") {
+			t.Errorf("zero fields must be omitted: %v", q)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"o1","clientOrderId":"c1","status":"FILLED_PARTIAL","side":1,"filled":"0.5","quantity":"1","postOnly":true,"timeInForce":"GTD","createdAt":1700000000001}]}`))
+	})
+
+	side := SELL
+	orders, err := Collect(cl.ListOrders(context.Background(), OrderFilter{
+		ProductIDs:    []string{"p1", "p2"},
+		Side:          &side,
+		Statuses:      []OrderStatus{STATUS_NEW},
+		CreatedAfter:  time.UnixMilli(1700000000000),
+		ClientOrderID: "c1",
+		PageOptions:   PageOptions{Limit: 50},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("orders: %+v", orders)
+	}
+	o := orders[0]
+	if o.Id != "o1" || o.Status != STATUS_FILLED_PARTIAL || !o.Status.Working() || o.Side != SELL || !o.PostOnly || o.Filled.String() != "0.5" {
+		t.Fatalf("decoded: %+v", o)
+	}
+}
+
+func TestGetOrder(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/order/o1" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"o1","status":"CANCELED","reduceOnly":true}`))
+	})
+
+	o, err := cl.GetOrder(context.Background(), "o1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Id != "o1" || o.Status != STATUS_CANCELED || o.Status.Working() || !o.ReduceOnly {
+		t.Fatalf("decoded: %+v", o)
+	}
+}
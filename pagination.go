@@ -0,0 +1,133 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"maps"
+	"net/url"
+	"strconv"
+)
+
+// -------- BEGIN PAGINATION -------- //
+
+type SortOrder string
+
+const (
+	SORT_ASC  SortOrder = "asc"
+	SORT_DESC SortOrder = "desc"
+)
+
+// Page is the venue's envelope for cursor paginated list endpoints.
+type Page[T any] struct {
+	Data       []T    `json:"data"`
+	HasNext    bool   `json:"hasNext"`
+	NextCursor string `json:"nextCursor"`
+}
+
+// PageOptions controls how a list endpoint is walked. Zero fields use the
+// venue defaults.
+type PageOptions struct {
+	Limit   int       // page size
+	Order   SortOrder // sort direction
+	OrderBy string    // sort field, e.g. "createdAt"
+	Cursor  string    // resume from a cursor returned by a previous page
+}
+
+func (p PageOptions) apply(q url.Values) {
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Order != "" {
+		q.Set("order", string(p.Order))
+	}
+	if p.OrderBy != "" {
+		q.Set("orderBy", p.OrderBy)
+	}
+	if p.Cursor != "" {
+		q.Set("cursor", p.Cursor)
+	}
+}
+
+// Pages walks a cursor paginated GET endpoint page by page. query holds the
+// endpoint's filters; paging parameters are taken from opts. Iteration stops
+// at the first error, including context cancellation.
+func Pages[T any](ctx context.Context, cl *Client, path string, query url.Values, opts PageOptions) iter.Seq2[*Page[T], error] {
+	return func(yield func(*Page[T], error) bool) {
+		q := maps.Clone(query)
+		if q == nil {
+			q = url.Values{}
+		}
+		opts.apply(q)
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			data, err := cl.Do(ctx, "GET", path+"?"+q.Encode(), nil)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			var page Page[T]
+			if err := json.Unmarshal(data, &page); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(&page, nil) || !page.HasNext || page.NextCursor == "" {
+				return
+			}
+			q.Set("cursor", page.NextCursor)
+		}
+	}
+}
+
+// Paginate walks a cursor paginated GET endpoint item by item.
+func Paginate[T any](ctx context.Context, cl *Client, path string, query url.Values, opts PageOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for page, err := range Pages[T](ctx, cl, path, query, opts) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Collect drains a list iterator into a slice, returning the first error.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var out []T
+	for item, err := range seq {
+		if err != nil {
+			return out, err
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// subaccountSeq resolves the client's subaccount before walking a list endpoint
+// scoped to it.
+func subaccountSeq[T any](ctx context.Context, cl *Client, list func(sub *Subaccount) iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		sub, err := cl.subaccount(ctx)
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for item, err := range list(sub) {
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
+// -------- END PAGINATION -------- //
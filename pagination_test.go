@@ -0,0 +1,89 @@
+package etherealRest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// pagedServer serves /items as three pages of two items each.
+func pagedServer(t *testing.T) *Client {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("kind") != "x" || q.Get("order") != "desc" || q.Get("limit") != "2" {
+			t.Errorf("query: %v", q)
+		}
+		n, _ := strconv.Atoi(q.Get("cursor"))
+		hasNext := n < 2
+		fmt.Fprintf(w, `{"hasNext":%v,"nextCursor":"%d","data":[%d,%d]}`, hasNext, n+1, 2*n, 2*n+1)
+	}))
+	t.Cleanup(ts.Close)
+	return &Client{BaseURL: ts.URL, Http: ts.Client()}
+}
+
+func TestPaginate_itemsAndPages(t *testing.T) {
+	cl := pagedServer(t)
+	query := url.Values{"kind": {"x"}}
+	opts := PageOptions{Limit: 2, Order: SORT_DESC}
+
+	var pages int
+	for page, err := range Pages[int](context.Background(), cl, "/items", query, opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Data) != 2 {
+			t.Fatalf("page: %+v", page)
+		}
+		pages++
+	}
+	if pages != 3 {
+		t.Fatalf("pages: %d", pages)
+	}
+
+	// iterators are reusable and do not leak cursor state
+	for range 2 {
+		items, err := Collect(Paginate[int](context.Background(), cl, "/items", query, opts))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 6 || items[5] != 5 {
+			t.Fatalf("items: %v", items)
+		}
+	}
+	if query.Has("cursor") {
+		t.Fatal("caller query must not be mutated")
+	}
+}
+
+func TestPaginate_resumeCursorAndCancel(t *testing.T) {
+	cl := pagedServer(t)
+	query := url.Values{"kind": {"x"}}
+
+	items, err := Collect(Paginate[int](context.Background(), cl, "/items", query, PageOptions{Limit: 2, Order: SORT_DESC, Cursor: "2"}))
+	if err != nil || len(items) != 2 || items[0] != 4 {
+		t.Fatalf("items: %v err: %v", items, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []int
+	for item, err := range Paginate[int](ctx, cl, "/items", query, PageOptions{Limit: 2, Order: SORT_DESC}) {
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			break
+		}
+		got = append(got, item)
+		cancel()
+	}
+	if len(got) != 2 {
+		t.Fatalf("cancellation must stop before the next page, got %v", got)
+	}
+}
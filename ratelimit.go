@@ -0,0 +1,167 @@
+package etherealRest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointClass groups venue endpoints that share a rate limit.
+type EndpointClass int
+
+const (
+	ClassPublic  EndpointClass = iota // unauthenticated market data and config
+	ClassAccount                      // subaccount, position, balance and order reads
+	ClassOrder                        // order placement and other signed writes
+	ClassCancel                       // order cancellation
+)
+
+func (c EndpointClass) String() string {
+	switch c {
+	case ClassPublic:
+		return "public"
+	case ClassAccount:
+		return "account"
+	case ClassOrder:
+		return "order"
+	case ClassCancel:
+		return "cancel"
+	}
+	return "unknown"
+}
+
+// SetRateLimiter replaces the client's limiter; nil disables client-side
+// throttling. A limiter may be shared by several clients.
+func (e *Client) SetRateLimiter(l *RateLimiter) {
+	e.limiter = l
+}
+
+// RateLimiter returns the limiter applied to every request, or nil.
+func (e *Client) RateLimiter() *RateLimiter {
+	return e.limiter
+}
+
+// ClassifyRequest maps a request to the rate limit class it is charged to.
+func ClassifyRequest(method, path string) EndpointClass {
+	path, query, _ := strings.Cut(path, "?")
+	if method != http.MethodGet {
+		if strings.HasPrefix(path, "/v1/order/cancel") {
+			return ClassCancel
+		}
+		return ClassOrder
+	}
+	switch {
+	case path == "/v1/order/trade": // public trade history
+		return ClassPublic
+	case strings.HasPrefix(path, "/v1/subaccount"),
+		strings.HasPrefix(path, "/v1/position"),
+		strings.HasPrefix(path, "/v1/order"),
+		strings.Contains(query, "subaccountId="):
+		return ClassAccount
+	}
+	return ClassPublic
+}
+
+// RateLimit configures a token bucket: Rate tokens are added per second up to
+// Burst. Each request costs one token.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// DefaultRateLimits are conservative per-class budgets; raise them with
+// SetLimit to match your venue tier.
+var DefaultRateLimits = map[EndpointClass]RateLimit{
+	ClassPublic:  {Rate: 20, Burst: 40},
+	ClassAccount: {Rate: 10, Burst: 20},
+	ClassOrder:   {Rate: 20, Burst: 40},
+	ClassCancel:  {Rate: 20, Burst: 40},
+}
+
+// RateLimiter is a client-side token bucket limiter keyed by EndpointClass.
+// Classes without a configured limit are not throttled. It is safe for
+// concurrent use and shared by every request sent through Client.Do.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[EndpointClass]*bucket
+}
+
+type bucket struct {
+	limit  RateLimit
+	tokens float64 // negative while callers hold reservations
+	last   time.Time
+}
+
+func NewRateLimiter(limits map[EndpointClass]RateLimit) *RateLimiter {
+	l := &RateLimiter{buckets: make(map[EndpointClass]*bucket, len(limits))}
+	for class, limit := range limits {
+		l.SetLimit(class, limit)
+	}
+	return l
+}
+
+// SetLimit replaces the limit for a class and refills its bucket.
+func (l *RateLimiter) SetLimit(class EndpointClass, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buckets[class] = &bucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+}
+
+// Budget returns the tokens currently available for a class. A negative value
+// means callers are already queued behind the limit. ok is false when the
+// class is not limited.
+func (l *RateLimiter) Budget(class EndpointClass) (tokens float64, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[class]
+	if !ok {
+		return 0, false
+	}
+	b.refill(time.Now())
+	return b.tokens, true
+}
+
+// Wait blocks until a token for class is available or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, class EndpointClass) error {
+	l.mu.Lock()
+	b, ok := l.buckets[class]
+	if !ok || b.limit.Rate <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	b.refill(now)
+	b.tokens-- // reserve, possibly going into debt
+	delay := time.Duration(-b.tokens / b.limit.Rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.tokens++ // give the reservation back
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+	b.tokens += elapsed * b.limit.Rate
+	if burst := float64(b.limit.Burst); b.tokens > burst {
+		b.tokens = burst
+	}
+}
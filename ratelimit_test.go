@@ -0,0 +1,89 @@
+package etherealRest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClassifyRequest(t *testing.T) {
+	cases := []struct {
+		method, path string
+		want         EndpointClass
+	}{
+		{http.MethodGet, "/v1/product", ClassPublic},
+		{http.MethodGet, "/v1/rpc/config", ClassPublic},
+		{http.MethodGet, "/v1/position?subaccountId=0x1&open=true", ClassAccount},
+		{http.MethodGet, "/v1/subaccount?sender=0xabc", ClassAccount},
+		{http.MethodGet, "/v1/order?subaccountId=0x1", ClassAccount},
+		{http.MethodGet, "/v1/order/oid-1", ClassAccount},
+		{http.MethodGet, "/v1/order/fill?subaccountId=0x1", ClassAccount},
+		{http.MethodGet, "/v1/order/trade?productId=p1&limit=50", ClassPublic},
+		{http.MethodPost, "/v1/order", ClassOrder},
+		{http.MethodPost, "/v1/order/cancel", ClassCancel},
+	}
+	for _, tc := range cases {
+		if got := ClassifyRequest(tc.method, tc.path); got != tc.want {
+			t.Fatalf("%s %s: got %v want %v", tc.method, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestRateLimiter_waitBlocksUntilRefill(t *testing.T) {
+	l := NewRateLimiter(map[EndpointClass]RateLimit{ClassOrder: {Rate: 100, Burst: 1}})
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 3 {
+		if err := l.Wait(ctx, ClassOrder); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Fatalf("expected throttling, took %v", elapsed)
+	}
+
+	// unlimited classes pass straight through
+	if err := l.Wait(ctx, ClassPublic); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := l.Budget(ClassPublic); ok {
+		t.Fatal("public class should be unlimited")
+	}
+}
+
+func TestRateLimiter_contextCancelRefunds(t *testing.T) {
+	l := NewRateLimiter(map[EndpointClass]RateLimit{ClassCancel: {Rate: 0.001, Burst: 1}})
+	if err := l.Wait(context.Background(), ClassCancel); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, ClassCancel); err == nil {
+		t.Fatal("expected context error")
+	}
+	if tokens, _ := l.Budget(ClassCancel); tokens < -0.01 {
+		t.Fatalf("reservation not refunded: %v", tokens)
+	}
+}
+
+func TestClient_Do_chargesLimiter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer ts.Close()
+
+	cl := &Client{BaseURL: ts.URL, Http: ts.Client()}
+	cl.SetRateLimiter(NewRateLimiter(map[EndpointClass]RateLimit{ClassPublic: {Rate: 0.001, Burst: 5}}))
+	for range 2 {
+		if _, err := cl.GetProductMap(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tokens, _ := cl.RateLimiter().Budget(ClassPublic); tokens > 3.01 {
+		t.Fatalf("budget not charged: %v", tokens)
+	}
+}
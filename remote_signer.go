@@ -0,0 +1,193 @@
+package etherealRest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// -------- BEGIN REMOTE SIGNER -------- //
+
+// The remote signing protocol is JSON-RPC 2.0 over HTTP POST with two methods:
+//
+//	signer_address    params: []                      result: "0x<20-byte address>"
+//	signer_signDigest params: ["0x<32-byte digest>"]  result: "0x<65-byte signature>"
+//
+// NewSignerHandler serves it for any DigestSigner.
+
+type rpcRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      uint64            `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("remote signer error %d: %s", e.Code, e.Message)
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      uint64          `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// RemoteSigner signs digests through a signing service speaking the remote
+// signing protocol, so the key never enters this process. Every signature it
+// returns is checked to recover to the service's address.
+type RemoteSigner struct {
+	URL     string
+	Http    *http.Client
+	address common.Address
+	id      atomic.Uint64
+}
+
+// NewRemoteSigner connects to the signing service at url and asks it for its
+// address. A nil client uses http.DefaultClient.
+func NewRemoteSigner(ctx context.Context, url string, client *http.Client) (*RemoteSigner, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	r := &RemoteSigner{URL: url, Http: client}
+	var address common.Address
+	if err := r.call(ctx, "signer_address", &address); err != nil {
+		return nil, err
+	}
+	r.address = address
+	return r, nil
+}
+
+func (r *RemoteSigner) Address() common.Address {
+	return r.address
+}
+
+func (r *RemoteSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var sig hexutil.Bytes
+	if err := r.call(ctx, "signer_signDigest", &sig, hexutil.Bytes(digest)); err != nil {
+		return nil, err
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("remote signer returned a %d byte signature, want 65", len(sig))
+	}
+
+	// recover with V normalized to 0/1 and make sure the service signed as the address it advertised
+	check := append([]byte(nil), sig...)
+	if check[64] >= 27 {
+		check[64] -= 27
+	}
+	pub, err := crypto.SigToPub(digest, check)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid signature: %w", err)
+	}
+	if got := crypto.PubkeyToAddress(*pub); got != r.address {
+		return nil, fmt.Errorf("remote signer signed as %s, expected %s", got, r.address)
+	}
+	return sig, nil
+}
+
+func (r *RemoteSigner) call(ctx context.Context, method string, result any, params ...any) error {
+	req := rpcRequest{JSONRPC: "2.0", ID: r.id.Add(1), Method: method, Params: []json.RawMessage{}}
+	for _, p := range params {
+		b, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		req.Params = append(req.Params, b)
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := r.Http.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("remote signer %s: HTTP %d: %s", method, resp.StatusCode, data)
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(data, &rpcResp); err != nil {
+		return err
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+	if rpcResp.ID != req.ID {
+		return fmt.Errorf("remote signer %s: response id %d does not match request %d", method, rpcResp.ID, req.ID)
+	}
+	return json.Unmarshal(rpcResp.Result, result)
+}
+
+// NewSignerHandler serves the remote signing protocol for s, e.g. as a local
+// stand-in for a signing service in tests:
+//
+//	srv := httptest.NewServer(rest.NewSignerHandler(rest.NewKeySigner(pk)))
+//	remote, err := rest.NewRemoteSigner(ctx, srv.URL, nil)
+func NewSignerHandler(s DigestSigner) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req rpcRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<16)).Decode(&req); err != nil {
+			writeRPC(w, rpcResponse{Error: &rpcError{Code: -32700, Message: "parse error"}})
+			return
+		}
+
+		resp := rpcResponse{ID: req.ID}
+		switch req.Method {
+		case "signer_address":
+			resp.Result, _ = json.Marshal(s.Address())
+		case "signer_signDigest":
+			var digest hexutil.Bytes
+			if len(req.Params) != 1 || json.Unmarshal(req.Params[0], &digest) != nil || len(digest) != 32 {
+				resp.Error = &rpcError{Code: -32602, Message: "expected a single 32-byte hex digest"}
+				break
+			}
+			sig, err := s.SignDigest(r.Context(), digest)
+			if err != nil {
+				resp.Error = &rpcError{Code: -32000, Message: err.Error()}
+				break
+			}
+			resp.Result, _ = json.Marshal(hexutil.Bytes(sig))
+		default:
+			resp.Error = &rpcError{Code: -32601, Message: "method not found"}
+		}
+		writeRPC(w, resp)
+	})
+}
+
+func writeRPC(w http.ResponseWriter, resp rpcResponse) {
+	resp.JSONRPC = "2.0"
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// -------- END REMOTE SIGNER -------- //
@@ -0,0 +1,36 @@
+package etherealRest
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRemoteSigner_rejectsForeignSignatures(t *testing.T) {
+	ctx := context.Background()
+	honest := httptest.NewServer(NewSignerHandler(NewKeySigner(mustECDSA(t, testKeyHex))))
+	defer honest.Close()
+	remote, err := NewRemoteSigner(ctx, honest.URL, honest.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// point the client at a service holding a different key
+	other, _ := crypto.GenerateKey()
+	impostor := httptest.NewServer(NewSignerHandler(NewKeySigner(other)))
+	defer impostor.Close()
+	remote.URL = impostor.URL
+
+	digest := crypto.Keccak256([]byte("digest"))
+	if _, err := remote.SignDigest(ctx, digest); err == nil || !strings.Contains(err.Error(), "signed as") {
+		t.Fatalf("expected an address mismatch, got %v", err)
+	}
+
+	remote.URL = honest.URL
+	if _, err := remote.SignDigest(ctx, digest[:31]); err == nil {
+		t.Fatal("expected the service to reject a short digest")
+	}
+}
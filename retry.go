@@ -0,0 +1,121 @@
+package etherealRest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how Client.Do retries transient failures.
+//
+// GET requests are always retried. Signed POSTs are only retried when the
+// message is idempotent (an Order carrying a ClientOrderID, or a cancel), and
+// every retry rebuilds the message so it is re-signed with a fresh Nonce and
+// SignedAt.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; <= 1 disables retries
+	BaseDelay   time.Duration // backoff before the second attempt, doubled on each retry
+	MaxDelay    time.Duration // cap on a single backoff (a larger Retry-After still wins)
+	Jitter      float64       // fraction of each backoff that is randomized, 0..1
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+	Jitter:      0.2,
+}
+
+// SetRetryPolicy replaces the client's retry policy.
+func (e *Client) SetRetryPolicy(p RetryPolicy) {
+	e.retryPolicy = &p
+}
+
+type retrier interface {
+	retry(ctx context.Context, idempotent bool, attempt func(n int) ([]byte, error)) ([]byte, error)
+}
+
+// idempotentMessage is implemented by signable messages that are safe to
+// submit more than once.
+type idempotentMessage interface {
+	idempotent() bool
+}
+
+func isIdempotent(msg Signable) bool {
+	m, ok := msg.(idempotentMessage)
+	return ok && m.idempotent()
+}
+
+func (e *Client) retry(ctx context.Context, idempotent bool, attempt func(n int) ([]byte, error)) ([]byte, error) {
+	maxAttempts := 1
+	if e.retryPolicy != nil && idempotent {
+		maxAttempts = e.retryPolicy.MaxAttempts
+	}
+
+	for n := 0; ; n++ {
+		out, err := attempt(n)
+		if err == nil || n+1 >= maxAttempts || !isRetryable(ctx, err) {
+			return out, err
+		}
+
+		delay := e.retryPolicy.backoff(n+1, err)
+		e.log().Warn("retrying request", "attempt", n+2, "delay", delay, "err", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay before the given retry (1-based).
+func (p *RetryPolicy) backoff(retry int, err error) time.Duration {
+	delay := p.BaseDelay << (retry - 1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+		delay = apiErr.RetryAfter
+	}
+	return delay
+}
+
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	// Timeouts and connections dropped mid-request are transient. DNS, TLS,
+	// refused connections and bad URLs fail the same way on every attempt.
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) // server closed a reused keep-alive connection
+}
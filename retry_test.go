@@ -0,0 +1,167 @@
+package etherealRest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var fastRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestClient_Do_retriesGet(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer ts.Close()
+
+	cl := &Client{BaseURL: ts.URL, Http: ts.Client()}
+	cl.SetRetryPolicy(fastRetryPolicy)
+	if _, err := cl.GetProductMap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("calls: %d", calls.Load())
+	}
+}
+
+func TestClient_Do_doesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	cl := &Client{BaseURL: ts.URL, Http: ts.Client()}
+	cl.SetRetryPolicy(fastRetryPolicy)
+	if _, err := cl.Do(context.Background(), http.MethodGet, "/x", nil); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("calls: %d", calls.Load())
+	}
+}
+
+func TestOrderSend_retryRequiresClientOrderID(t *testing.T) {
+	var calls atomic.Int32
+	var nonces []string
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		var msg SignedMessage[*Order]
+		_ = json.Unmarshal(b, &msg)
+		nonces = append(nonces, msg.Data.Nonce)
+		if calls.Add(1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"oid1","clientOrderId":"abc","filled":"0","result":"ok"}`))
+	})
+	cl.SetRetryPolicy(fastRetryPolicy)
+
+	order := NewRawOrder(ORDER_LIMIT, 1, PERPETUAL, DecimalFromInt(1), DecimalFromInt(100), false, BUY, TIF_GTD)
+	_, err := cl.CreateOrder(context.Background(), order)
+	if !errors.Is(err, ErrMaintenance) || calls.Load() != 1 {
+		t.Fatalf("order without client id must not be retried: calls=%d err=%v", calls.Load(), err)
+	}
+
+	calls.Store(0)
+	nonces = nil
+	order.ClientOrderID = "abc"
+	created, err := cl.CreateOrder(context.Background(), order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Id != "oid1" || calls.Load() != 2 {
+		t.Fatalf("calls=%d created=%+v", calls.Load(), created)
+	}
+	if nonces[0] == nonces[1] {
+		t.Fatal("retry must re-sign with a fresh nonce")
+	}
+}
+
+func TestRetryPolicy_honorsRetryAfter(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	err := &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second}
+	if d := p.backoff(1, err); d != 2*time.Second {
+		t.Fatalf("backoff: %v", d)
+	}
+	if d := p.backoff(5, errors.New("reset")); d != time.Millisecond {
+		t.Fatalf("backoff cap: %v", d)
+	}
+}
+
+func TestClient_retry_contextCancel(t *testing.T) {
+	cl := &Client{}
+	cl.SetRetryPolicy(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int
+	_, err := cl.retry(ctx, true, func(int) ([]byte, error) {
+		calls++
+		cancel()
+		return nil, &APIError{StatusCode: http.StatusBadGateway}
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("calls=%d err=%v", calls, err)
+	}
+}
+
+func TestClient_Do_doesNotRetryTLSErrors(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer ts.Close()
+
+	// the default client does not trust the test server's certificate
+	cl := &Client{BaseURL: ts.URL, Http: &http.Client{}}
+	cl.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour})
+	var certErr *tls.CertificateVerificationError
+	if _, err := cl.Do(context.Background(), http.MethodGet, "/x", nil); !errors.As(err, &certErr) {
+		t.Fatalf("expected a certificate error, got %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Fatalf("calls: %d", calls.Load())
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	urlErr := func(err error) error { return &url.Error{Op: "Get", URL: "https://x", Err: err} }
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"timeout":            {urlErr(&net.DNSError{Err: "i/o timeout", IsTimeout: true}), true},
+		"connection reset":   {urlErr(&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true},
+		"connection aborted": {urlErr(syscall.ECONNABORTED), true},
+		"closed keep-alive":  {urlErr(io.EOF), true},
+		"truncated body":     {io.ErrUnexpectedEOF, true},
+		"no such host":       {urlErr(&net.DNSError{Err: "no such host", Name: "x", IsNotFound: true}), false},
+		"refused":            {urlErr(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), false},
+		"bad scheme":         {urlErr(errors.New(`unsupported protocol scheme "ftp"`)), false},
+		"bad certificate":    {urlErr(x509.UnknownAuthorityError{}), false},
+		"bad request":        {&APIError{StatusCode: http.StatusBadRequest}, false},
+		"unavailable":        {&APIError{StatusCode: http.StatusServiceUnavailable}, true},
+	}
+	for name, c := range cases {
+		if got := isRetryable(context.Background(), c.err); got != c.want {
+			t.Errorf("%s: retryable %v, want %v", name, got, c.want)
+		}
+	}
+}
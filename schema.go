@@ -0,0 +1,161 @@
+package etherealRest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// -------- BEGIN SCHEMA DRIFT -------- //
+
+// signedSchema is the EIP-712 struct a Signable emits from ToMessage.
+type signedSchema struct {
+	primaryType string
+	message     Signable // zero value, used by tests to keep fields in step with ToMessage
+	fields      []abi.Type
+	required    bool // trading needs it, so the venue must define it
+}
+
+var signedSchemas = []signedSchema{
+	{"TradeOrder", &Order{}, tradeOrderLayout.fields, true},
+	{"CancelOrder", &OrderCancel{}, cancelOrderLayout.fields, true},
+	{"LinkSigner", &LinkedSignerMessage{}, linkedSignerFields, false},
+	{"RefreshLinkedSigner", &LinkedSignerMessage{}, linkedSignerFields, false},
+	{"RevokeLinkedSigner", &LinkedSignerMessage{}, linkedSignerFields, false},
+}
+
+func mustParseTypeSchema(schema string) []abi.Type {
+	fields, err := ParseTypeSchema(schema)
+	if err != nil {
+		panic(err)
+	}
+	return fields
+}
+
+// FieldChange is a field signed under a different type than the venue expects.
+type FieldChange struct {
+	Name   string
+	Client string // type the client signs
+	Venue  string // type in the venue schema
+}
+
+// SchemaDrift is how the venue's schema for one primary type differs from the
+// fields the client signs. Field order is not compared: messages are hashed in
+// the venue's order.
+type SchemaDrift struct {
+	PrimaryType string
+	Undefined   bool       // the venue does not define the primary type
+	Missing     []abi.Type // signed by the client, not in the venue schema
+	Extra       []abi.Type // in the venue schema, not signed by the client
+	Retyped     []FieldChange
+}
+
+func (d SchemaDrift) String() string {
+	if d.Undefined {
+		return d.PrimaryType + ": not defined by the venue"
+	}
+	var parts []string
+	for _, f := range d.Missing {
+		parts = append(parts, fmt.Sprintf("missing %s %s", f.Type, f.Name))
+	}
+	for _, f := range d.Extra {
+		parts = append(parts, fmt.Sprintf("extra %s %s", f.Type, f.Name))
+	}
+	for _, f := range d.Retyped {
+		parts = append(parts, fmt.Sprintf("retyped %s %s -> %s", f.Name, f.Client, f.Venue))
+	}
+	return d.PrimaryType + ": " + strings.Join(parts, ", ")
+}
+
+// SchemaDriftError lists every primary type whose venue schema differs from
+// what the client signs. It matches ErrSchemaDrift.
+type SchemaDriftError struct {
+	Drift []SchemaDrift
+}
+
+func (e *SchemaDriftError) Error() string {
+	parts := make([]string, len(e.Drift))
+	for i, d := range e.Drift {
+		parts[i] = d.String()
+	}
+	return "ethereal: EIP-712 schema drift: " + strings.Join(parts, "; ")
+}
+
+func (e *SchemaDriftError) Unwrap() error {
+	return ErrSchemaDrift
+}
+
+// affects reports whether primaryType is one of the drifted types.
+func (e *SchemaDriftError) affects(primaryType string) bool {
+	return slices.ContainsFunc(e.Drift, func(d SchemaDrift) bool { return d.PrimaryType == primaryType })
+}
+
+// CheckSchema compares the venue's EIP-712 types with the fields every
+// Signable emits, by name and type. It returns a *SchemaDriftError listing
+// the differences, or nil when they all match. Optional types the venue does
+// not define (linked signers) are skipped.
+func CheckSchema(types *abi.TypedData) error {
+	var drift []SchemaDrift
+	for _, s := range signedSchemas {
+		if _, defined := types.Types[s.primaryType]; !defined && !s.required {
+			continue
+		}
+		if d, ok := compareSchema(types, s.primaryType, s.fields); !ok {
+			drift = append(drift, d)
+		}
+	}
+	if drift == nil {
+		return nil
+	}
+	return &SchemaDriftError{Drift: drift}
+}
+
+func compareSchema(types *abi.TypedData, primaryType string, signed []abi.Type) (SchemaDrift, bool) {
+	d := SchemaDrift{PrimaryType: primaryType}
+	venue, ok := types.Types[primaryType]
+	if !ok {
+		d.Undefined = true
+		return d, false
+	}
+	for _, f := range signed {
+		i := slices.IndexFunc(venue, func(v abi.Type) bool { return v.Name == f.Name })
+		switch {
+		case i < 0:
+			d.Missing = append(d.Missing, f)
+		case venue[i].Type != f.Type:
+			d.Retyped = append(d.Retyped, FieldChange{Name: f.Name, Client: f.Type, Venue: venue[i].Type})
+		}
+	}
+	for _, v := range venue {
+		if !slices.ContainsFunc(signed, func(f abi.Type) bool { return f.Name == v.Name }) {
+			d.Extra = append(d.Extra, v)
+		}
+	}
+	return d, d.Missing == nil && d.Extra == nil && d.Retyped == nil
+}
+
+// SchemaDrift returns the differences found between the venue's EIP-712
+// schema and the client's messages when the types were loaded, or nil.
+func (e *Client) SchemaDrift() *SchemaDriftError {
+	return e.schemaDrift.Load()
+}
+
+// checkSchema refuses to sign primaryType when its schema drifted, unless the
+// client was built WithSchemaDriftAllowed.
+func (e *Client) checkSchema(primaryType string) error {
+	drift := e.schemaDrift.Load()
+	if drift == nil || e.allowDrift || !drift.affects(primaryType) {
+		return nil
+	}
+	return fmt.Errorf("refusing to sign %s: %w", primaryType, drift)
+}
+
+// schemaChecker is implemented by clients that validate the venue schema
+// before signing.
+type schemaChecker interface {
+	checkSchema(primaryType string) error
+}
+
+// -------- END SCHEMA DRIFT -------- //
@@ -0,0 +1,143 @@
+package etherealRest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func TestSignedSchemas_matchToMessage(t *testing.T) {
+	for _, s := range signedSchemas {
+		m, err := s.message.ToMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var emitted, declared []string
+		for name := range m {
+			emitted = append(emitted, name)
+		}
+		for _, f := range s.fields {
+			declared = append(declared, f.Name)
+		}
+		slices.Sort(emitted)
+		slices.Sort(declared)
+		if !slices.Equal(emitted, declared) {
+			t.Errorf("%s: ToMessage emits %v, schema declares %v", s.primaryType, emitted, declared)
+		}
+	}
+}
+
+// driftedTypedData moves the venue's TradeOrder away from what orders sign:
+// price is dropped, nonce widened and a fee field added.
+func driftedTypedData(t *testing.T) *abi.TypedData {
+	t.Helper()
+	types := testTypedData(t)
+	var fields []abi.Type
+	for _, f := range types.Types["TradeOrder"] {
+		switch f.Name {
+		case "price":
+			continue
+		case "nonce":
+			f.Type = "uint128"
+		}
+		fields = append(fields, f)
+	}
+	types.Types["TradeOrder"] = append(fields, abi.Type{Name: "fee", Type: "uint32"})
+	return types
+}
+
+func TestCheckSchema(t *testing.T) {
+	if err := CheckSchema(testTypedData(t)); err != nil {
+		t.Fatalf("venue schema reported as drifted: %v", err)
+	}
+	if err := CheckSchema(linkedTypedData(t)); err != nil {
+		t.Fatalf("linked signer schema reported as drifted: %v", err)
+	}
+
+	types := driftedTypedData(t)
+	delete(types.Types, "CancelOrder")
+	err := CheckSchema(types)
+	var drift *SchemaDriftError
+	if !errors.Is(err, ErrSchemaDrift) || !errors.As(err, &drift) {
+		t.Fatalf("expected a *SchemaDriftError, got %v", err)
+	}
+	if len(drift.Drift) != 2 {
+		t.Fatalf("drift %+v", drift.Drift)
+	}
+	trade := drift.Drift[0]
+	if len(trade.Missing) != 1 || trade.Missing[0].Name != "price" ||
+		len(trade.Extra) != 1 || trade.Extra[0].Name != "fee" ||
+		len(trade.Retyped) != 1 || trade.Retyped[0] != (FieldChange{Name: "nonce", Client: "uint64", Venue: "uint128"}) {
+		t.Fatalf("TradeOrder drift %+v", trade)
+	}
+	if !drift.Drift[1].Undefined {
+		t.Fatalf("CancelOrder drift %+v", drift.Drift[1])
+	}
+	want := "TradeOrder: missing uint128 price, extra uint32 fee, retyped nonce uint64 -> uint128; CancelOrder: not defined by the venue"
+	if !strings.HasSuffix(err.Error(), want) {
+		t.Fatalf("error %q", err)
+	}
+}
+
+func TestClient_refusesDriftedSchema(t *testing.T) {
+	var orders atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/subaccount":
+			_ = json.NewEncoder(w).Encode(Response[[]Subaccount]{Data: []Subaccount{{
+				Id:      "sub-1",
+				Name:    "0x2222222222222222222222222222222222222222222222222222222222222222",
+				Account: r.URL.Query().Get("sender"),
+			}}})
+		case "/v1/order":
+			orders.Add(1)
+			_, _ = w.Write([]byte(`{"id":"o1"}`))
+		case "/v1/order/cancel":
+			_, _ = w.Write([]byte(`{"data":[{"id":"o1","result":"Ok"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	ctx := context.Background()
+	order := func() *Order {
+		return testProduct().NewOrder(ORDER_LIMIT, MustDecimal("1"), MustDecimal("100"), false, BUY, TIF_GTD)
+	}
+
+	cl, err := NewClient(ctx, WithPrivateKey(testKeyHex), WithBaseURL(ts.URL), WithTypedData(driftedTypedData(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cl.SchemaDrift() == nil {
+		t.Fatal("drift not recorded")
+	}
+	if _, err := cl.CreateOrder(ctx, order()); !errors.Is(err, ErrSchemaDrift) {
+		t.Fatalf("expected ErrSchemaDrift, got %v", err)
+	}
+	if orders.Load() != 0 {
+		t.Fatal("a drifted order reached the venue")
+	}
+	// cancels are unaffected, so open orders can still be pulled
+	if _, err := cl.CancelOrder(ctx, NewCancel("o1")); err != nil {
+		t.Fatal(err)
+	}
+
+	// with the override a retyped field is signed under the venue's type
+	retyped := testTypedData(t)
+	retyped.Types["TradeOrder"][8].Type = "uint128"
+	cl, err = NewClient(ctx, WithPrivateKey(testKeyHex), WithBaseURL(ts.URL), WithTypedData(retyped), WithSchemaDriftAllowed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.CreateOrder(ctx, order()); err != nil || orders.Load() != 1 {
+		t.Fatalf("override did not sign: %v", err)
+	}
+}
@@ -2,33 +2,125 @@ package etherealRest
 
 import (
 	"crypto/ecdsa"
+	"fmt"
+	"sync"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
 	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 type Signer struct {
 	Subaccount *Subaccount
-	types      *abi.TypedData
-	pk         *ecdsa.PrivateKey
+	key        DigestSigner
 	Address    string
+
+	mu         sync.RWMutex
+	types      *abi.TypedData
+	domainHash []byte // precomputed by InitDomain
+	nonces     NonceSource
 }
 
 func NewSigner(pk *ecdsa.PrivateKey) *Signer {
+	return NewDigestSigner(NewKeySigner(pk))
+}
+
+// NewDigestSigner returns a signer backed by a DigestSigner, e.g. a
+// RemoteSigner, so the private key never has to be loaded in this process.
+func NewDigestSigner(key DigestSigner) *Signer {
 	return &Signer{
-		pk:      pk,
-		Address: crypto.PubkeyToAddress(pk.PublicKey).Hex(),
+		key:     key,
+		Address: key.Address().Hex(),
+	}
+}
+
+// NewWatchSigner returns a read-only signer for an address whose key is not
+// held by this process. It can resolve subaccounts but not sign.
+func NewWatchSigner(address string) (*Signer, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address %q", address)
 	}
+	return &Signer{Address: common.HexToAddress(address).Hex()}, nil
+}
+
+// ReadOnly reports whether the signer holds no key. A nil signer is read-only.
+func (r *Signer) ReadOnly() bool {
+	return r == nil || r.key == nil
 }
 
+// GetDigestSigner returns the signer's key, nil when it cannot sign.
+func (r *Signer) GetDigestSigner() DigestSigner {
+	if r == nil {
+		return nil
+	}
+	return r.key
+}
+
+// GetPk returns the private key of signers built by NewSigner, and nil for
+// any other DigestSigner.
+//
+// Deprecated: sign through GetDigestSigner instead.
 func (r *Signer) GetPk() *ecdsa.PrivateKey {
-	return r.pk
+	if r == nil {
+		return nil
+	}
+	if k, ok := r.key.(*KeySigner); ok {
+		return k.privateKey()
+	}
+	return nil
 }
 
 func (r *Signer) SetTypes(t *abi.TypedData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.types = t
 }
 
 func (r *Signer) GetTypes() *abi.TypedData {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	return r.types
 }
+
+func (r *Signer) SetDomainHash(h []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.domainHash = h
+}
+
+func (r *Signer) GetDomainHash() []byte {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.domainHash
+}
+
+// SetNonceSource replaces the source of the signer's order and cancel nonces.
+func (r *Signer) SetNonceSource(n NonceSource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nonces = n
+}
+
+// Nonces returns the signer's NonceSource: a MonotonicNonce of its own unless
+// SetNonceSource was called.
+func (r *Signer) Nonces() NonceSource {
+	r.mu.RLock()
+	n := r.nonces
+	r.mu.RUnlock()
+	if n != nil {
+		return n
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.nonces == nil {
+		r.nonces, _ = NewMonotonicNonce(nil) // cannot fail without a store
+	}
+	return r.nonces
+}
+
+// withKey returns a signer for key sharing r's EIP-712 types and domain.
+func (r *Signer) withKey(key DigestSigner) *Signer {
+	s := NewDigestSigner(key)
+	s.SetTypes(r.GetTypes())
+	s.SetDomainHash(r.GetDomainHash())
+	return s
+}
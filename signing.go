@@ -1,7 +1,7 @@
 package etherealRest
 
 import (
-	"crypto/ecdsa"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -11,8 +11,6 @@ import (
 	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
-var DomainHash []byte // precomputed by InitDomain
-
 // ------- HELPERS -------
 func ParseTypeSchema(typeString string) ([]abi.Type, error) {
 	fields := strings.Split(typeString, ",")
@@ -36,15 +34,14 @@ func ParseTypeSchema(typeString string) ([]abi.Type, error) {
 	return args, nil
 }
 
+// Scale1e9 converts a decimal string to its 1e9-scaled integer. It fails
+// rather than truncate when s has more than 9 decimal places.
 func Scale1e9(s string) (*big.Int, error) {
-	r := new(big.Rat)
-	if _, ok := r.SetString(s); !ok {
-		return nil, fmt.Errorf("bad decimal %q", s)
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return nil, err
 	}
-	r.Mul(r, big.NewRat(1_000_000_000, 1))
-	n := new(big.Int)
-	n.Div(r.Num(), r.Denom())
-	return n, nil
+	return d.Scaled(), nil
 }
 
 type SignedMessage[T Signable] struct {
@@ -53,33 +50,36 @@ type SignedMessage[T Signable] struct {
 }
 
 type CanSign interface {
-	GetPk() *ecdsa.PrivateKey
+	GetDigestSigner() DigestSigner // nil when the signer cannot sign
 	GetTypes() *abi.TypedData
+	GetDomainHash() []byte
 }
 
 type Signable interface {
-	Build(cl SubaccountHolder)
+	Build(cl SubaccountHolder) error // fills sender, subaccount and a fresh nonce
 	ToMessage() (abi.TypedDataMessage, error)
 }
 
 func Sign(message Signable, primaryType string, signer CanSign) (string, error) {
-	msg, err := message.ToMessage()
-	if err != nil {
-		return "", err
-	}
+	return SignContext(context.Background(), message, primaryType, signer)
+}
 
-	messageHash, err := signer.GetTypes().HashStruct(primaryType, msg)
+// SignContext is Sign with a context for digest signers that call out to a
+// remote service.
+func SignContext(ctx context.Context, message Signable, primaryType string, signer CanSign) (string, error) {
+	ds := signer.GetDigestSigner()
+	if ds == nil {
+		return "", ErrReadOnly
+	}
+	fullHash, err := messageDigest(signer.GetTypes(), signer.GetDomainHash(), message, primaryType)
 	if err != nil {
 		return "", err
 	}
 
-	fullHash := MakeFullHash(DomainHash, messageHash)
-
-	sig, err := crypto.Sign(fullHash, signer.GetPk())
+	sig, err := signDigest(ctx, ds, fullHash)
 	if err != nil {
 		return "", err
 	}
-	sig[64] += 27 // recovery byte fix  |  much love to _0xmer :)
 
 	return "0x" + hex.EncodeToString(sig), nil
 }
@@ -1,13 +1,16 @@
 package etherealRest
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"math/big"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
@@ -38,6 +41,9 @@ func TestScale1e9(t *testing.T) {
 	if _, err := Scale1e9("not-a-number"); err == nil {
 		t.Fatal("expected error")
 	}
+	if _, err := Scale1e9("0.0000000001"); err == nil {
+		t.Fatal("expected error instead of silent truncation")
+	}
 }
 
 func TestParseTypeSchema(t *testing.T) {
@@ -65,7 +71,7 @@ func TestMakeFullHash(t *testing.T) {
 }
 
 func TestSignedMessage_JSON_roundTrip(t *testing.T) {
-	o := &Order{Quantity: "1", Price: "1"}
+	o := &Order{Quantity: MustDecimal("1"), Price: MustDecimal("1")}
 	payload := SignedMessage[*Order]{Data: o, Signature: "0xabc"}
 	b, err := json.Marshal(payload)
 	if err != nil {
@@ -75,7 +81,7 @@ func TestSignedMessage_JSON_roundTrip(t *testing.T) {
 	if err := json.Unmarshal(b, &out); err != nil {
 		t.Fatal(err)
 	}
-	if out.Signature != "0xabc" || out.Data.Quantity != "1" {
+	if out.Signature != "0xabc" || out.Data.Quantity.String() != "1" {
 		t.Fatalf("%+v", out)
 	}
 }
@@ -84,8 +90,8 @@ func TestOrderToMessage_encodings(t *testing.T) {
 	order := Order{
 		Sender:     "0xdeadbeef00000000000000000000000000000000",
 		Subaccount: "0x123456789abcde00000000000000000000000000000000000000000000000000",
-		Quantity:   "1",
-		Price:      "3000",
+		Quantity:   MustDecimal("1"),
+		Price:      MustDecimal("3000"),
 		ReduceOnly: false,
 		Side:       BUY,
 		EngineType: PERPETUAL,
@@ -126,3 +132,63 @@ func TestOrderToMessage_encodings(t *testing.T) {
 	check("uint128", "quantity", "quantity", "000000000000000000000000000000000000000000000000000000003b9aca00")
 	check("uint128", "price", "price", "000000000000000000000000000000000000000000000000000002ba7def3000")
 }
+
+func TestSign_perSignerDomain(t *testing.T) {
+	base := testTypedData(t)
+	mainnet := *base
+	mainnet.Domain.ChainId = math.NewHexOrDecimal256(1)
+
+	key := mustECDSA(t, "0bb5d63b84421e1268dda020818ae30cf26e7f10e321fb820a8aa69216dea92a")
+	signers := []*Signer{NewSigner(key), NewSigner(key)}
+	for i, types := range []*abi.TypedData{base, &mainnet} {
+		cl := &Client{account: signers[i]}
+		if _, err := cl.useTypes(types); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if bytes.Equal(signers[0].GetDomainHash(), signers[1].GetDomainHash()) {
+		t.Fatal("different chain ids must produce different domain hashes")
+	}
+
+	order := &Order{
+		Sender:     signers[0].Address,
+		Subaccount: "0x123456789abcde00000000000000000000000000000000000000000000000000",
+		Quantity:   MustDecimal("1"),
+		Price:      MustDecimal("3000"),
+		Nonce:      "1764897077655477722",
+		SignedAt:   1764897077,
+	}
+	sigs := make([]string, len(signers))
+	var wg sync.WaitGroup
+	for i, s := range signers {
+		wg.Go(func() {
+			sig, err := Sign(order, "TradeOrder", s)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			sigs[i] = sig
+		})
+	}
+	wg.Wait()
+	if sigs[0] == sigs[1] {
+		t.Fatal("signatures for different domains must differ")
+	}
+
+	msg, _ := order.ToMessage()
+	for i, s := range signers {
+		messageHash, err := s.GetTypes().HashStruct("TradeOrder", msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, _ := hex.DecodeString(strings.TrimPrefix(sigs[i], "0x"))
+		raw[64] -= 27
+		pub, err := crypto.SigToPub(MakeFullHash(s.GetDomainHash(), messageHash), raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if crypto.PubkeyToAddress(*pub).Hex() != s.Address {
+			t.Fatalf("signer %d: signature does not recover under its own domain", i)
+		}
+	}
+}
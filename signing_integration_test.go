@@ -27,8 +27,8 @@ func TestLive_orderSigning_againstTestnet(t *testing.T) {
 	order := Order{
 		Sender:     "0xdeadbeef00000000000000000000000000000000",
 		Subaccount: "0x123456789abcde00000000000000000000000000000000000000000000000000",
-		Quantity:   "1",
-		Price:      "3000",
+		Quantity:   MustDecimal("1"),
+		Price:      MustDecimal("3000"),
 		ReduceOnly: false,
 		Side:       BUY,
 		EngineType: PERPETUAL,
@@ -38,7 +38,7 @@ func TestLive_orderSigning_againstTestnet(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	client, err := NewClient(ctx, pk, Testnet)
+	client, err := NewClient(ctx, WithPrivateKey(pk), WithEnvironment(Testnet))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -61,7 +61,7 @@ func TestLive_orderSigning_againstTestnet(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	signer := &integrationTestSigner{pk: mustIntegrationKey(t, pk), types: client.GetTypes()}
+	signer := &integrationTestSigner{pk: mustIntegrationKey(t, pk), types: client.GetTypes(), domain: client.account.GetDomainHash()}
 
 	signature, err := Sign(&order, "TradeOrder", signer)
 	if err != nil {
@@ -90,8 +90,9 @@ func TestLive_orderSigning_againstTestnet(t *testing.T) {
 }
 
 type integrationTestSigner struct {
-	pk    *ecdsa.PrivateKey
-	types *abi.TypedData
+	pk     *ecdsa.PrivateKey
+	types  *abi.TypedData
+	domain []byte
 }
 
 func mustIntegrationKey(t *testing.T, pk string) *ecdsa.PrivateKey {
@@ -103,14 +104,18 @@ func mustIntegrationKey(t *testing.T, pk string) *ecdsa.PrivateKey {
 	return k
 }
 
-func (s *integrationTestSigner) GetPk() *ecdsa.PrivateKey {
-	return s.pk
+func (s *integrationTestSigner) GetDigestSigner() DigestSigner {
+	return NewKeySigner(s.pk)
 }
 
 func (s *integrationTestSigner) GetTypes() *abi.TypedData {
 	return s.types
 }
 
+func (s *integrationTestSigner) GetDomainHash() []byte {
+	return s.domain
+}
+
 func reverseHexIntegration(s string) ([]byte, error) {
 	clean := strings.TrimPrefix(s, "0x")
 	return hex.DecodeString(clean)
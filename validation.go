@@ -0,0 +1,177 @@
+package etherealRest
+
+import (
+	"fmt"
+)
+
+// -------- BEGIN VALIDATION -------- //
+
+// PriceRounding selects how a limit price is moved onto the product's tick.
+type PriceRounding int
+
+const (
+	PRICE_PASSIVE    PriceRounding = iota // buys round down, sells round up
+	PRICE_AGGRESSIVE                      // buys round up, sells round down
+	PRICE_NEAREST                         // nearest tick, ties to even
+)
+
+// RoundingPolicy controls how orders built from a Product are normalized
+// before signing. The zero value rounds prices passively and quantities down.
+type RoundingPolicy struct {
+	Price    PriceRounding
+	Quantity RoundingMode
+	Strict   bool // reject off-tick and off-lot values instead of rounding
+
+	// MaxOrderNotional caps the notional of a single order. Zero caps orders
+	// that can grow the position at the product's MaxPositionNotionalUsd,
+	// which no such order may exceed on its own unless it flips the
+	// position; set a larger cap to allow that. ValidatePosition checks the
+	// whole position.
+	MaxOrderNotional Decimal
+}
+
+// ValidationError reports the product constraint an order violates.
+type ValidationError struct {
+	Field      string // "price", "stopPrice", "quantity", "notional" or "positionNotional"
+	Constraint string // "tickSize", "lotSize", "minQuantity", "maxQuantity", "maxOrderNotional" or "maxPositionNotional"
+	Value      Decimal
+	Limit      Decimal
+}
+
+func (e *ValidationError) Error() string {
+	switch e.Constraint {
+	case "tickSize", "lotSize":
+		return fmt.Sprintf("invalid order: %s %s is not a multiple of %s %s", e.Field, e.Value, e.Constraint, e.Limit)
+	case "minQuantity":
+		return fmt.Sprintf("invalid order: %s %s is below %s %s", e.Field, e.Value, e.Constraint, e.Limit)
+	}
+	return fmt.Sprintf("invalid order: %s %s exceeds %s %s", e.Field, e.Value, e.Constraint, e.Limit)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidOrder
+}
+
+// SetRoundingPolicy replaces the policy used to normalize orders before signing.
+func (e *Client) SetRoundingPolicy(p RoundingPolicy) {
+	e.rounding = p
+}
+
+func (e *Client) RoundingPolicy() RoundingPolicy {
+	return e.rounding
+}
+
+// Validate checks an order against the product's tick size, lot size and
+// quantity bounds, and the per-order notional cap, without modifying it. Zero
+// valued constraints are not enforced.
+func (p *Product) Validate(o *Order, policy RoundingPolicy) error {
+	if o.Type != ORDER_MARKET && !o.Price.IsMultipleOf(p.TickSize) {
+		return &ValidationError{Field: "price", Constraint: "tickSize", Value: o.Price, Limit: p.TickSize}
+	}
+	if o.StopType != nil && !o.StopPrice.IsMultipleOf(p.TickSize) {
+		return &ValidationError{Field: "stopPrice", Constraint: "tickSize", Value: o.StopPrice, Limit: p.TickSize}
+	}
+	if o.Close && o.Quantity.IsZero() {
+		return nil // closes the whole position, sized by the venue
+	}
+	if !o.Quantity.IsMultipleOf(p.LotSize) {
+		return &ValidationError{Field: "quantity", Constraint: "lotSize", Value: o.Quantity, Limit: p.LotSize}
+	}
+	if o.Quantity.LessThan(p.MinQuantity) || o.Quantity.Sign() <= 0 {
+		return &ValidationError{Field: "quantity", Constraint: "minQuantity", Value: o.Quantity, Limit: p.MinQuantity}
+	}
+	if p.MaxQuantity.Sign() > 0 && o.Quantity.GreaterThan(p.MaxQuantity) {
+		return &ValidationError{Field: "quantity", Constraint: "maxQuantity", Value: o.Quantity, Limit: p.MaxQuantity}
+	}
+	limit, constraint := policy.MaxOrderNotional, "maxOrderNotional"
+	if limit.IsZero() && !o.ReduceOnly {
+		limit, constraint = p.MaxPositionNotionalUsd, "maxPositionNotional"
+	}
+	if notional := o.Quantity.Mul(o.Price); limit.Sign() > 0 && notional.GreaterThan(limit) {
+		return &ValidationError{Field: "notional", Constraint: constraint, Value: notional, Limit: limit}
+	}
+	return nil
+}
+
+// ValidatePosition checks the position left after o fills in full, valued at
+// the order's price, against the product's MaxPositionNotionalUsd. pos is the
+// current position in the product, nil when flat. Reduce-only and closing
+// orders, market orders without a price and products without a limit pass.
+func (p *Product) ValidatePosition(pos *Position, o *Order) error {
+	if o.ReduceOnly || o.Close || o.Price.IsZero() || p.MaxPositionNotionalUsd.Sign() <= 0 {
+		return nil
+	}
+	size := o.Quantity
+	if o.Side == SELL {
+		size = size.Neg()
+	}
+	if pos != nil {
+		if pos.ProductId != p.ID {
+			return fmt.Errorf("position %s is in product %s, not %s", pos.Id, pos.ProductId, p.ID)
+		}
+		current := pos.Size.Abs()
+		if pos.Side == SELL {
+			current = current.Neg()
+		}
+		size = size.Add(current)
+	}
+	if notional := size.Abs().Mul(o.Price); notional.GreaterThan(p.MaxPositionNotionalUsd) {
+		return &ValidationError{Field: "positionNotional", Constraint: "maxPositionNotional", Value: notional, Limit: p.MaxPositionNotionalUsd}
+	}
+	return nil
+}
+
+// Normalize rounds the order's price to the product's tick and its quantity to
+// the lot size according to policy, then validates it.
+func (p *Product) Normalize(o *Order, policy RoundingPolicy) error {
+	if !policy.Strict {
+		if o.Type != ORDER_MARKET {
+			o.Price = o.Price.RoundToStep(p.TickSize, policy.priceMode(o.Side))
+		}
+		if o.StopType != nil {
+			o.StopPrice = o.StopPrice.RoundToStep(p.TickSize, policy.priceMode(o.Side))
+		}
+		o.Quantity = o.Quantity.RoundToStep(p.LotSize, policy.Quantity)
+	}
+	return p.Validate(o, policy)
+}
+
+func (r RoundingPolicy) priceMode(side OrderSide) RoundingMode {
+	switch r.Price {
+	case PRICE_NEAREST:
+		return ROUND_HALF_EVEN
+	case PRICE_AGGRESSIVE:
+		if side == BUY {
+			return ROUND_CEIL
+		}
+		return ROUND_FLOOR
+	}
+	if side == BUY {
+		return ROUND_FLOOR
+	}
+	return ROUND_CEIL
+}
+
+// normalizer is implemented by messages that are checked against product
+// constraints before they are signed.
+type normalizer interface {
+	normalize(policy RoundingPolicy) error
+}
+
+// normalize applies the product's constraints when the order was built from a
+// Product; orders from NewRawOrder are sent as is.
+func (o *Order) normalize(policy RoundingPolicy) error {
+	if o.product == nil {
+		return nil
+	}
+	return o.product.Normalize(o, policy)
+}
+
+func roundingPolicyOf(cl OrderClient) RoundingPolicy {
+	if h, ok := cl.(interface{ RoundingPolicy() RoundingPolicy }); ok {
+		return h.RoundingPolicy()
+	}
+	return RoundingPolicy{}
+}
+
+// -------- END VALIDATION -------- //
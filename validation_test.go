@@ -0,0 +1,155 @@
+package etherealRest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func testProduct() *Product {
+	return &Product{
+		ID:                     "p1",
+		Ticker:                 "ETHUSD",
+		OnchainID:              1,
+		TickSize:               MustDecimal("0.5"),
+		LotSize:                MustDecimal("0.01"),
+		MinQuantity:            MustDecimal("0.01"),
+		MaxQuantity:            MustDecimal("10"),
+		MaxPositionNotionalUsd: MustDecimal("10000"),
+	}
+}
+
+func TestProductNormalize_rounding(t *testing.T) {
+	p := testProduct()
+	cases := []struct {
+		side   OrderSide
+		policy RoundingPolicy
+		px     string
+		qty    string
+		wantPx string
+		wantQ  string
+	}{
+		{BUY, RoundingPolicy{}, "1000.3", "0.129", "1000", "0.12"},
+		{SELL, RoundingPolicy{}, "1000.3", "0.129", "1000.5", "0.12"},
+		{BUY, RoundingPolicy{Price: PRICE_AGGRESSIVE, Quantity: ROUND_UP}, "1000.3", "0.121", "1000.5", "0.13"},
+		{SELL, RoundingPolicy{Price: PRICE_NEAREST}, "1000.2", "1", "1000", "1"},
+	}
+	for _, tc := range cases {
+		o := p.NewOrder(ORDER_LIMIT, MustDecimal(tc.qty), MustDecimal(tc.px), false, tc.side, TIF_GTD)
+		if err := p.Normalize(o, tc.policy); err != nil {
+			t.Fatal(err)
+		}
+		if o.Price.String() != tc.wantPx || o.Quantity.String() != tc.wantQ {
+			t.Fatalf("%+v: got px %s qty %s", tc, o.Price, o.Quantity)
+		}
+	}
+
+}
+
+func TestProductValidate_constraints(t *testing.T) {
+	p := testProduct()
+	cases := []struct {
+		policy     RoundingPolicy
+		px, qty    string
+		reduce     bool
+		constraint string
+	}{
+		{RoundingPolicy{Strict: true}, "1000.3", "1", false, "tickSize"},
+		{RoundingPolicy{Strict: true}, "1000", "1.005", false, "lotSize"},
+		{RoundingPolicy{}, "1000", "0.005", false, "minQuantity"},
+		{RoundingPolicy{}, "100", "11", false, "maxQuantity"},
+		{RoundingPolicy{MaxOrderNotional: MustDecimal("100")}, "1000", "1", true, "maxOrderNotional"},
+		{RoundingPolicy{}, "2000", "6", false, "maxPositionNotional"},
+	}
+	for _, tc := range cases {
+		o := p.NewOrder(ORDER_LIMIT, MustDecimal(tc.qty), MustDecimal(tc.px), tc.reduce, BUY, TIF_GTD)
+		err := p.Normalize(o, tc.policy)
+		var verr *ValidationError
+		if !errors.As(err, &verr) || verr.Constraint != tc.constraint {
+			t.Fatalf("%+v: expected %s violation, got %v", tc, tc.constraint, err)
+		}
+		if !errors.Is(err, ErrInvalidOrder) {
+			t.Fatal("validation errors must match ErrInvalidOrder")
+		}
+	}
+
+	// reduce-only orders and an explicit cap lift the position limit default
+	o := p.NewOrder(ORDER_LIMIT, MustDecimal("6"), MustDecimal("2000"), true, BUY, TIF_GTD)
+	if err := p.Validate(o, RoundingPolicy{}); err != nil {
+		t.Fatal(err)
+	}
+	o.ReduceOnly = false
+	if err := p.Validate(o, RoundingPolicy{MaxOrderNotional: MustDecimal("20000")}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProductValidatePosition(t *testing.T) {
+	p := testProduct() // MaxPositionNotionalUsd 10000
+	long := &Position{Id: "pos1", ProductId: "p1", Size: MustDecimal("4"), Side: BUY}
+	cases := []struct {
+		pos    *Position
+		side   OrderSide
+		qty    string
+		reduce bool
+		ok     bool
+	}{
+		{nil, BUY, "4", false, true},   // flat + 4 @ 2000 = 8000
+		{nil, SELL, "6", false, false}, // flat - 6 @ 2000 = 12000
+		{long, BUY, "1", false, true},  // 4 + 1 = 10000
+		{long, BUY, "2", false, false}, // 4 + 2 = 12000
+		{long, SELL, "9", false, true}, // 4 - 9 = -5, 10000
+		{long, BUY, "6", true, true},   // reduce-only never grows the position
+	}
+	for _, tc := range cases {
+		o := p.NewOrder(ORDER_LIMIT, MustDecimal(tc.qty), MustDecimal("2000"), tc.reduce, tc.side, TIF_GTD)
+		err := p.ValidatePosition(tc.pos, o)
+		var verr *ValidationError
+		if tc.ok != (err == nil) || (err != nil && (!errors.As(err, &verr) || verr.Constraint != "maxPositionNotional")) {
+			t.Fatalf("%+v: got %v", tc, err)
+		}
+	}
+	if err := p.ValidatePosition(&Position{ProductId: "p2"}, p.NewOrder(ORDER_LIMIT, MustDecimal("1"), MustDecimal("1"), false, BUY, TIF_GTD)); err == nil {
+		t.Fatal("expected a product mismatch error")
+	}
+}
+
+func TestSend_rejectsInvalidOrdersBeforeSigning(t *testing.T) {
+	var posts atomic.Int32
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		posts.Add(1)
+		_, _ = w.Write([]byte(`{"id":"oid1","result":"ok"}`))
+	})
+	p := testProduct()
+	ctx := context.Background()
+
+	if _, err := cl.CreateOrder(ctx, p.NewOrder(ORDER_LIMIT, MustDecimal("50"), MustDecimal("100"), false, BUY, TIF_GTD)); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("expected ErrInvalidOrder, got %v", err)
+	}
+	// 6 @ 2000 is over the product's 10000 notional limit
+	if _, err := cl.CreateOrder(ctx, p.NewOrder(ORDER_LIMIT, MustDecimal("6"), MustDecimal("2000"), false, BUY, TIF_GTD)); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("expected ErrInvalidOrder, got %v", err)
+	}
+
+	orders := []*Order{
+		p.NewOrder(ORDER_LIMIT, MustDecimal("1"), MustDecimal("100.2"), false, BUY, TIF_GTD),
+		p.NewOrder(ORDER_LIMIT, MustDecimal("0.001"), MustDecimal("100"), false, BUY, TIF_GTD),
+		p.NewOrder(ORDER_LIMIT, MustDecimal("6"), MustDecimal("2000"), false, SELL, TIF_GTD),
+	}
+	if _, err := cl.CreateOrders(ctx, orders); !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("expected ErrInvalidOrder, got %v", err)
+	}
+	if posts.Load() != 0 {
+		t.Fatalf("invalid orders must not reach the venue, posts=%d", posts.Load())
+	}
+
+	created, err := cl.CreateOrder(ctx, orders[0])
+	if err != nil || created.Id != "oid1" {
+		t.Fatalf("created: %+v err: %v", created, err)
+	}
+	if orders[0].Price.String() != "100" {
+		t.Fatalf("price not rounded to tick: %s", orders[0].Price)
+	}
+}
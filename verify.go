@@ -0,0 +1,158 @@
+package etherealRest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	abi "github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// -------- BEGIN SIGNATURE VERIFICATION -------- //
+
+// EncodedField is one field of an EIP-712 struct as it enters the struct hash.
+type EncodedField struct {
+	Name    string
+	Type    string
+	Value   any    // value taken from the message
+	Encoded []byte // 32-byte ABI word; dynamic types are already hashed
+}
+
+// SigningBreakdown is every intermediate value of an EIP-712 digest, for
+// comparing against what the venue or another implementation computed.
+type SigningBreakdown struct {
+	PrimaryType string
+	Domain      abi.TypedDataDomain
+	DomainHash  []byte
+	EncodedType string // e.g. "CancelOrder(address sender,bytes32 subaccount,uint64 nonce)"
+	TypeHash    []byte
+	Fields      []EncodedField
+	StructHash  []byte
+	Digest      []byte // keccak256(0x19 0x01 || DomainHash || StructHash), the signed value
+}
+
+func (b *SigningBreakdown) String() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "primaryType: %s\n", b.PrimaryType)
+	fmt.Fprintf(&s, "domain:      name=%q version=%q chainId=%v verifyingContract=%s\n",
+		b.Domain.Name, b.Domain.Version, b.Domain.ChainId, b.Domain.VerifyingContract)
+	fmt.Fprintf(&s, "domainHash:  0x%x\n", b.DomainHash)
+	fmt.Fprintf(&s, "encodedType: %s\n", b.EncodedType)
+	fmt.Fprintf(&s, "typeHash:    0x%x\n", b.TypeHash)
+	for _, f := range b.Fields {
+		fmt.Fprintf(&s, "  %-10s %-12s = %v\n             0x%x\n", f.Type, f.Name, f.Value, f.Encoded)
+	}
+	fmt.Fprintf(&s, "structHash:  0x%x\n", b.StructHash)
+	fmt.Fprintf(&s, "digest:      0x%x\n", b.Digest)
+	return s.String()
+}
+
+// BreakDown computes the EIP-712 digest of msg exactly as Sign does, keeping
+// every intermediate value. The domain hash is recomputed from types rather
+// than taken from a signer's cache.
+func BreakDown(types *abi.TypedData, msg Signable, primaryType string) (*SigningBreakdown, error) {
+	if types == nil {
+		return nil, fmt.Errorf("no EIP-712 types; initialize the client or use WithTypedData")
+	}
+	if _, ok := types.Types[primaryType]; !ok {
+		return nil, fmt.Errorf("primary type %q is not in the typed data", primaryType)
+	}
+	m, err := msg.ToMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &SigningBreakdown{
+		PrimaryType: primaryType,
+		Domain:      types.Domain,
+		EncodedType: string(types.EncodeType(primaryType)),
+		TypeHash:    types.TypeHash(primaryType),
+	}
+	if b.DomainHash, err = types.HashStruct("EIP712Domain", types.Domain.Map()); err != nil {
+		return nil, fmt.Errorf("domain: %w", err)
+	}
+	encoded, err := types.EncodeData(primaryType, m, 1)
+	if err != nil {
+		return nil, err
+	}
+	// EncodeData is the type hash followed by one 32-byte word per field, in schema order
+	for i, field := range types.Types[primaryType] {
+		b.Fields = append(b.Fields, EncodedField{
+			Name:    field.Name,
+			Type:    field.Type,
+			Value:   m[field.Name],
+			Encoded: encoded[32*(i+1) : 32*(i+2)],
+		})
+	}
+	b.StructHash = crypto.Keccak256(encoded)
+	b.Digest = MakeFullHash(b.DomainHash, b.StructHash)
+	return b, nil
+}
+
+// RecoverSigner returns the address that produced sig over msg. Signatures
+// with a recovery byte of 27/28 (as produced by Sign) and 0/1 are accepted.
+func RecoverSigner(types *abi.TypedData, msg Signable, primaryType, sig string) (common.Address, *SigningBreakdown, error) {
+	b, err := BreakDown(types, msg, primaryType)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	raw, err := hex.DecodeString(strings.TrimPrefix(sig, "0x"))
+	if err != nil {
+		return common.Address{}, b, fmt.Errorf("signature is not hex: %w", err)
+	}
+	if len(raw) != 65 {
+		return common.Address{}, b, fmt.Errorf("signature is %d bytes, want 65", len(raw))
+	}
+	if raw[64] >= 27 {
+		raw[64] -= 27
+	}
+	pub, err := crypto.SigToPub(b.Digest, raw)
+	if err != nil {
+		return common.Address{}, b, err
+	}
+	return crypto.PubkeyToAddress(*pub), b, nil
+}
+
+// SignatureReport is the outcome of VerifySignature.
+type SignatureReport struct {
+	Expected  common.Address
+	Recovered common.Address
+	Breakdown *SigningBreakdown
+}
+
+// VerifySignature checks sig over msg was made by expectedAddr. A mismatch is
+// reported as an error matching ErrInvalidSignature; the report, with the
+// recovered address and digest breakdown, is returned whenever the digest
+// could be computed.
+func VerifySignature(types *abi.TypedData, msg Signable, primaryType, sig, expectedAddr string) (*SignatureReport, error) {
+	if !common.IsHexAddress(expectedAddr) {
+		return nil, fmt.Errorf("invalid expected address %q", expectedAddr)
+	}
+	recovered, b, err := RecoverSigner(types, msg, primaryType, sig)
+	if b == nil {
+		return nil, err
+	}
+	report := &SignatureReport{Expected: common.HexToAddress(expectedAddr), Recovered: recovered, Breakdown: b}
+	if err != nil {
+		return report, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if recovered != report.Expected {
+		return report, fmt.Errorf("%w: %s signed as %s, expected %s", ErrInvalidSignature, primaryType, recovered, report.Expected)
+	}
+	return report, nil
+}
+
+// VerifySignature checks sig over msg against the client's EIP-712 types.
+func (e *Client) VerifySignature(msg Signable, primaryType, sig, expectedAddr string) (*SignatureReport, error) {
+	return VerifySignature(e.GetTypes(), msg, primaryType, sig, expectedAddr)
+}
+
+// RecoverSigner returns the address that made sig over msg under the client's
+// EIP-712 types.
+func (e *Client) RecoverSigner(msg Signable, primaryType, sig string) (common.Address, *SigningBreakdown, error) {
+	return RecoverSigner(e.GetTypes(), msg, primaryType, sig)
+}
+
+// -------- END SIGNATURE VERIFICATION -------- //
@@ -0,0 +1,86 @@
+package etherealRest
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestVerifySignature(t *testing.T) {
+	types := testTypedData(t)
+	key := mustECDSA(t, testKeyHex)
+	signer := NewSigner(key)
+	cl := &Client{account: signer}
+	if _, err := cl.useTypes(types); err != nil {
+		t.Fatal(err)
+	}
+
+	order := &Order{
+		Sender:     signer.Address,
+		Subaccount: "0x123456789abcde00000000000000000000000000000000000000000000000000",
+		Quantity:   MustDecimal("1.5"),
+		Price:      MustDecimal("3000"),
+		Side:       SELL,
+		OnchainID:  2,
+		Nonce:      "1764897077655477722",
+		SignedAt:   1764897077,
+	}
+	sig, err := Sign(order, "TradeOrder", signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := cl.VerifySignature(order, "TradeOrder", sig, strings.ToLower(signer.Address))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := report.Breakdown
+	if !bytes.Equal(b.DomainHash, signer.GetDomainHash()) {
+		t.Fatal("breakdown domain hash differs from the signer's")
+	}
+	if b.EncodedType != "TradeOrder(address sender,bytes32 subaccount,uint128 quantity,uint128 price,bool reduceOnly,uint8 side,uint8 engineType,uint32 productId,uint64 nonce,uint64 signedAt)" {
+		t.Fatalf("encoded type %s", b.EncodedType)
+	}
+	if len(b.Fields) != 10 || b.Fields[2].Name != "quantity" || new(big.Int).SetBytes(b.Fields[2].Encoded).Int64() != 1_500_000_000 || b.Fields[5].Encoded[31] != 1 {
+		t.Fatalf("fields %+v", b.Fields)
+	}
+	if !strings.Contains(b.String(), "structHash:") {
+		t.Fatalf("breakdown:\n%s", b)
+	}
+
+	// 0/1 recovery bytes are accepted too
+	raw := []byte(sig)
+	v := sig[len(sig)-2:]
+	low := map[string]string{"1b": "00", "1c": "01"}[v]
+	if addr, _, err := cl.RecoverSigner(order, "TradeOrder", string(raw[:len(raw)-2])+low); err != nil || addr.Hex() != signer.Address {
+		t.Fatalf("v=%s: recovered %s, %v", low, addr, err)
+	}
+
+	// a signature over another domain, message or key recovers someone else
+	other := *types
+	other.Domain.ChainId = math.NewHexOrDecimal256(1)
+	if _, err := VerifySignature(&other, order, "TradeOrder", sig, signer.Address); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("wrong domain: %v", err)
+	}
+	tampered := *order
+	tampered.Price = MustDecimal("3001")
+	if report, err := cl.VerifySignature(&tampered, "TradeOrder", sig, signer.Address); !errors.Is(err, ErrInvalidSignature) || report.Recovered.Hex() == signer.Address {
+		t.Fatalf("tampered message: %v", err)
+	}
+	stranger, _ := crypto.GenerateKey()
+	if _, err := cl.VerifySignature(order, "TradeOrder", sig, crypto.PubkeyToAddress(stranger.PublicKey).Hex()); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("wrong key: %v", err)
+	}
+
+	if _, err := cl.VerifySignature(order, "TradeOrder", "0x1234", signer.Address); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("short signature: %v", err)
+	}
+	if _, err := cl.VerifySignature(order, "Nope", sig, signer.Address); err == nil {
+		t.Fatal("expected an unknown type error")
+	}
+}